@@ -0,0 +1,64 @@
+// Package cmd provides command-line interface commands for the odinnordico.github.io application.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/generator"
+	"github.com/odinnordico/odinnordico.github.io/internal/loader"
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+	"github.com/odinnordico/odinnordico.github.io/internal/utils"
+)
+
+// ExportCmd represents the export command for writing machine-readable resume data.
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export resume data as JSON Resume and canonical JSON",
+	Long: `Export writes the merged data of every language as a JSON Resume resume.json and a canonical
+data.json dump of the resume model. The default language is written to the output directory
+and other languages to a subdirectory (e.g., es/resume.json).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataDir := viper.GetString("data-dir")
+		outputDir := viper.GetString("output-dir")
+
+		logger.Logger().Info("Starting data export...")
+		logger.Logger().Info("Data directory", "dir", dataDir)
+		logger.Logger().Info("Output directory", "dir", outputDir)
+
+		if err := utils.ValidateDirectories(dataDir); err != nil {
+			return fmt.Errorf("directory validation failed: %w", err)
+		}
+
+		// Validate resume data before exporting anything
+		if err := ValidateData(dataDir); err != nil {
+			return err
+		}
+
+		return ExportMultiLanguageData(dataDir, outputDir, utils.DefaultLang)
+	},
+}
+
+// ExportMultiLanguageData writes the JSON exports for the target language.
+// If targetLang is empty or utils.DefaultLang, it exports all available languages.
+func ExportMultiLanguageData(dataDir, outputDir, targetLang string) error {
+	exportGen := generator.NewExportGenerator(outputDir)
+
+	for _, lang := range detectLanguages(dataDir, targetLang) {
+		logger.Logger().Info("Exporting data for language", "lang", lang)
+
+		data, err := loader.LoadResumeData(dataDir, lang)
+		if err != nil {
+			return fmt.Errorf("failed to load resume data for %s: %w", lang, err)
+		}
+
+		if err := exportGen.Generate(data, lang); err != nil {
+			return fmt.Errorf("failed to export data for %s: %w", lang, err)
+		}
+	}
+
+	logger.Logger().Info("Data export completed successfully!")
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportMultiLanguageData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_export_data")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outputDir := filepath.Join(tempDir, "output")
+	if err := os.MkdirAll(filepath.Join(dataDir, "lang", "es"), 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "basic.yml"), []byte("name: Test User\n"), 0644); err != nil {
+		t.Fatalf("Failed to create data file: %v", err)
+	}
+
+	t.Run("Export all languages", func(t *testing.T) {
+		err := ExportMultiLanguageData(dataDir, outputDir, "en")
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(outputDir, "resume.json"))
+		assert.FileExists(t, filepath.Join(outputDir, "data.json"))
+		assert.FileExists(t, filepath.Join(outputDir, "es", "resume.json"))
+		assert.FileExists(t, filepath.Join(outputDir, "es", "data.json"))
+	})
+}
@@ -0,0 +1,97 @@
+// Package cmd provides command-line interface commands for the odinnordico.github.io application.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/i18n"
+	"github.com/odinnordico/odinnordico.github.io/internal/utils"
+)
+
+// I18nCmd represents the i18n command for translation tooling.
+var I18nCmd = &cobra.Command{
+	Use:   "i18n",
+	Short: "Inspect the translations of the resume data",
+	Long:  `I18n groups commands that inspect the data/lang/<lang> overlays.`,
+}
+
+// I18nStatusCmd represents the i18n status command.
+var I18nStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report translation coverage for every language",
+	Long: `Status compares each data/lang/<lang> overlay against the base data, field by field, and lists
+the text fields that are untranslated, stale (the base changed after the translation) or present
+only in the overlay, together with a per-language completeness percentage.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataDir := viper.GetString("data-dir")
+		format, _ := cmd.Flags().GetString("format")
+
+		if err := utils.ValidateDirectories(dataDir); err != nil {
+			return fmt.Errorf("directory validation failed: %w", err)
+		}
+
+		reports, err := TranslationStatus(dataDir)
+		if err != nil {
+			return err
+		}
+
+		return writeTranslationStatus(cmd.OutOrStdout(), reports, format)
+	},
+}
+
+func init() {
+	I18nStatusCmd.Flags().String("format", "text", "output format: text or json")
+	I18nCmd.AddCommand(I18nStatusCmd)
+}
+
+// TranslationStatus builds a translation coverage report for every language other than the default.
+func TranslationStatus(dataDir string) ([]*i18n.Report, error) {
+	reports := []*i18n.Report{}
+	for _, lang := range detectLanguages(dataDir, utils.DefaultLang) {
+		if lang == utils.DefaultLang {
+			continue
+		}
+
+		report, err := i18n.Status(dataDir, lang)
+		if err != nil {
+			return nil, fmt.Errorf("translation status for %s: %w", lang, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// writeTranslationStatus prints the reports as a human-readable summary or as JSON.
+func writeTranslationStatus(w io.Writer, reports []*i18n.Report, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	case "text":
+		for _, r := range reports {
+			fmt.Fprintf(w, "%s: %.1f%% complete (%d/%d translated, %d stale, %d untranslated, %d overlay only)\n",
+				r.Lang, r.Completeness, r.Translated, r.Total, r.Stale, r.Untranslated, r.OverlayOnly)
+			for _, f := range r.Fields {
+				file, line := f.OverlayFile, f.OverlayLine
+				if file == "" {
+					file, line = f.BaseFile, f.BaseLine
+				}
+				field := f.Section + "." + f.Path
+				if strings.HasPrefix(f.Path, "[") {
+					field = f.Section + f.Path
+				}
+				fmt.Fprintf(w, "  %-13s %s:%d: %s\n", f.Status, file, line, field)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q, use text or json", format)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/i18n"
+)
+
+func TestTranslationStatus(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_translation_status")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	langDir := filepath.Join(tempDir, "lang", "es")
+	if err := os.MkdirAll(langDir, 0755); err != nil {
+		t.Fatalf("Failed to create lang dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "basic.yml"), []byte("name: Test User\nsummary: Builds things\nphrase: Keep going\n"), 0644); err != nil {
+		t.Fatalf("Failed to create data file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(langDir, "basic.yml"), []byte("summary: Construye cosas\n"), 0644); err != nil {
+		t.Fatalf("Failed to create data file: %v", err)
+	}
+
+	reports, err := TranslationStatus(tempDir)
+	assert.NoError(t, err)
+	if !assert.Len(t, reports, 1) {
+		return
+	}
+	assert.Equal(t, "es", reports[0].Lang)
+	assert.Equal(t, 50.0, reports[0].Completeness)
+
+	t.Run("Text output", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, writeTranslationStatus(&buf, reports, "text"))
+		assert.Contains(t, buf.String(), "es: 50.0% complete (1/2 translated, 0 stale, 1 untranslated, 0 overlay only)")
+		assert.Contains(t, buf.String(), "untranslated  "+filepath.Join(tempDir, "basic.yml")+":3: basic.phrase")
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, writeTranslationStatus(&buf, reports, "json"))
+		var decoded []i18n.Report
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, 1, decoded[0].Untranslated)
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		assert.Error(t, writeTranslationStatus(&bytes.Buffer{}, reports, "xml"))
+	})
+}
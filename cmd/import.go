@@ -0,0 +1,67 @@
+// Package cmd provides command-line interface commands for the odinnordico.github.io application.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/jsonresume"
+	"github.com/odinnordico/odinnordico.github.io/internal/loader"
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+)
+
+// ImportCmd represents the import command for converting resumes from other formats.
+var ImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import resume data from other formats",
+	Long:  `Import converts resumes kept in other formats into the YAML data files used by this tool.`,
+}
+
+// ImportJSONResumeCmd represents the import jsonresume command.
+var ImportJSONResumeCmd = &cobra.Command{
+	Use:   "jsonresume <file>",
+	Short: "Import a JSON Resume (resume.json) file",
+	Long: `Import a jsonresume.org resume.json file and write its basics, work, education, certificates,
+skills and profiles as the per-section YAML files (basic.yml, professional.yml, ...) into --data-dir.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataDir := viper.GetString("data-dir")
+		force, _ := cmd.Flags().GetBool("force")
+
+		logger.Logger().Info("Importing JSON Resume", "file", args[0])
+		logger.Logger().Info("Data directory", "dir", dataDir)
+
+		return ImportJSONResume(args[0], dataDir, force)
+	},
+}
+
+func init() {
+	ImportJSONResumeCmd.Flags().Bool("force", false, "overwrite existing data files")
+	ImportCmd.AddCommand(ImportJSONResumeCmd)
+}
+
+// ImportJSONResume converts the JSON Resume file at path into YAML data files in dataDir.
+func ImportJSONResume(path, dataDir string, overwrite bool) error {
+	resume, err := jsonresume.Load(path)
+	if err != nil {
+		return fmt.Errorf("load JSON Resume: %w", err)
+	}
+
+	data, err := jsonresume.ToResumeData(resume)
+	if err != nil {
+		return fmt.Errorf("convert JSON Resume: %w", err)
+	}
+
+	written, err := loader.SaveResumeData(dataDir, data, overwrite)
+	for _, file := range written {
+		logger.Logger().Info("Wrote data file", "file", file)
+	}
+	if err != nil {
+		return fmt.Errorf("save resume data: %w", err)
+	}
+
+	logger.Logger().Info("JSON Resume import completed successfully!")
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportJSONResume(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_import_jsonresume")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	resumePath := filepath.Join(tempDir, "resume.json")
+	content := `{"basics": {"name": "Test User", "label": "Engineer"}, "skills": [{"name": "Go", "level": "Expert"}]}`
+	if err := os.WriteFile(resumePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create resume file: %v", err)
+	}
+	dataDir := filepath.Join(tempDir, "data")
+
+	t.Run("Import into empty data directory", func(t *testing.T) {
+		err := ImportJSONResume(resumePath, dataDir, false)
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dataDir, "basic.yml"))
+		assert.FileExists(t, filepath.Join(dataDir, "professional.yml"))
+		assert.FileExists(t, filepath.Join(dataDir, "skills.yml"))
+		assert.NoError(t, ValidateData(dataDir))
+	})
+
+	t.Run("Existing files require overwrite", func(t *testing.T) {
+		assert.Error(t, ImportJSONResume(resumePath, dataDir, false))
+		assert.NoError(t, ImportJSONResume(resumePath, dataDir, true))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		assert.Error(t, ImportJSONResume(filepath.Join(tempDir, "missing.json"), dataDir, true))
+	})
+}
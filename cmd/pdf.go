@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -14,6 +16,7 @@ import (
 	"github.com/odinnordico/odinnordico.github.io/internal/logger"
 	"github.com/odinnordico/odinnordico.github.io/internal/models"
 	"github.com/odinnordico/odinnordico.github.io/internal/utils"
+	"github.com/odinnordico/odinnordico.github.io/internal/vcs"
 )
 
 // PdfCmd represents the pdf command for generating PDF resumes.
@@ -25,6 +28,7 @@ var PdfCmd = &cobra.Command{
 		dataDir := viper.GetString("data-dir")
 		theme := viper.GetString("theme")
 		outputDir := viper.GetString("output-dir")
+		pageSize := viper.GetString("page-size")
 
 		logger.Logger().Info("Starting PDF generation...")
 		logger.Logger().Info("Data directory", "dir", dataDir)
@@ -35,17 +39,86 @@ var PdfCmd = &cobra.Command{
 			return fmt.Errorf("directory validation failed: %w", err)
 		}
 
-		return GenerateMultiLanguagePdf(dataDir, outputDir, utils.DefaultLang, theme)
+		// Validate resume data before generating anything
+		if err := ValidateData(dataDir); err != nil {
+			return err
+		}
+
+		timestamp, err := pdfTimestamp(dataDir, viper.GetBool("reproducible"), viper.GetString("timestamp"))
+		if err != nil {
+			return err
+		}
+		if !timestamp.IsZero() {
+			logger.Logger().Info("Reproducible PDF", "timestamp", timestamp.Format(time.RFC3339))
+		}
+
+		maxPages := viper.GetInt("max-pages")
+		if maxPages < 0 {
+			return fmt.Errorf("max pages cannot be negative, got %d", maxPages)
+		}
+
+		options, err := themeOptions()
+		if err != nil {
+			return err
+		}
+		if err := validateThemeOptions(theme, options); err != nil {
+			return err
+		}
+
+		return GenerateMultiLanguagePdf(dataDir, outputDir, utils.DefaultLang, theme,
+			generator.WithPageSize(pageSize), generator.WithTimestamp(timestamp), generator.WithMaxPages(maxPages),
+			generator.WithPDFThemeOptions(options))
 	},
 }
 
 func init() {
 	PdfCmd.Flags().String("theme", "default", "Theme name to use")
+	PdfCmd.Flags().String("page-size", "", "page size overriding the template's: A4, Letter, Legal or <width>x<height> in mm")
+	PdfCmd.Flags().Bool("reproducible", false, "pin PDF timestamps to SOURCE_DATE_EPOCH or the last commit of the data directory")
+	PdfCmd.Flags().String("timestamp", "", "pin PDF timestamps to this time, as RFC 3339 or YYYY-MM-DD")
+	PdfCmd.Flags().Int("max-pages", 0, "shrink fonts and spacing within the theme's bounds until the PDF fits in this many pages")
 	viper.BindPFlag("theme", PdfCmd.Flags().Lookup("theme"))
+	viper.BindPFlag("page-size", PdfCmd.Flags().Lookup("page-size"))
+	viper.BindPFlag("reproducible", PdfCmd.Flags().Lookup("reproducible"))
+	viper.BindPFlag("timestamp", PdfCmd.Flags().Lookup("timestamp"))
+	viper.BindPFlag("max-pages", PdfCmd.Flags().Lookup("max-pages"))
 }
 
-// detectLanguages determines which languages to generate PDFs for based on the target language
-// and available language directories. If targetLang is empty or utils.DefaultLang, it auto-detects all available languages.
+// pdfTimestamp returns the time every timestamp of the PDFs is pinned to, so that unchanged data
+// renders to byte-identical files: the configured timestamp, then SOURCE_DATE_EPOCH, then, in
+// reproducible mode, the time of the last commit of the data directory. Otherwise it returns
+// the zero time, and the PDFs carry the time they are generated.
+func pdfTimestamp(dataDir string, reproducible bool, configured string) (time.Time, error) {
+	if configured != "" {
+		for _, layout := range []string{time.RFC3339, time.DateOnly} {
+			if t, err := time.Parse(layout, configured); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: use RFC 3339 or YYYY-MM-DD", configured)
+	}
+
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	if !reproducible {
+		return time.Time{}, nil
+	}
+	t, err := vcs.LastCommitTime(dataDir)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reproducible PDF needs a timestamp, SOURCE_DATE_EPOCH or committed data: %w", err)
+	}
+	return t.UTC(), nil
+}
+
+// detectLanguages determines which languages to generate, export or report on based on the target
+// language and the language directories of data/lang. If targetLang is empty or utils.DefaultLang,
+// it auto-detects all available languages, the default one first.
 func detectLanguages(dataDir, targetLang string) []string {
 	langDirName := "lang"
 	languages := []string{utils.DefaultLang}
@@ -61,6 +134,10 @@ func detectLanguages(dataDir, targetLang string) []string {
 			return err
 		}
 		logger.Logger().Debug("Language directory", "entry", d.Name())
+		// Languages are the directories of data/lang, not the directories in them such as posts
+		if d.IsDir() && path != langDir && filepath.Dir(path) != langDir {
+			return filepath.SkipDir
+		}
 		if d.IsDir() && d.Name() != utils.DefaultLang && d.Name() != langDirName {
 			languages = append(languages, d.Name())
 		}
@@ -72,7 +149,7 @@ func detectLanguages(dataDir, targetLang string) []string {
 
 // GenerateMultiLanguagePdf generates a PDF resume for the specified language using the given data and theme.
 // If targetLang is empty or utils.DefaultLang, it auto-detects all available languages.
-func GenerateMultiLanguagePdf(dataDir, outputDir, targetLang, theme string) error {
+func GenerateMultiLanguagePdf(dataDir, outputDir, targetLang, theme string, opts ...generator.PDFOption) error {
 	languages := detectLanguages(dataDir, targetLang)
 	logger.Logger().Info("Target Lang", "lang", targetLang)
 	logger.Logger().Info("Languages to generate", "langs", languages)
@@ -86,7 +163,7 @@ func GenerateMultiLanguagePdf(dataDir, outputDir, targetLang, theme string) erro
 			return fmt.Errorf("failed to load resume data for %s: %w", lang, err)
 		}
 
-		if err := GeneratePDF(data, outputDir, lang, theme); err != nil {
+		if err := GeneratePDF(data, outputDir, lang, theme, opts...); err != nil {
 			return fmt.Errorf("failed to generate PDF for %s: %w", lang, err)
 		}
 	}
@@ -96,11 +173,14 @@ func GenerateMultiLanguagePdf(dataDir, outputDir, targetLang, theme string) erro
 }
 
 // GeneratePDF generates a PDF resume for the specified language using the given data and theme.
-func GeneratePDF(data *models.ResumeData, outputDir, lang, theme string) error {
+func GeneratePDF(data *models.ResumeData, outputDir, lang, theme string, opts ...generator.PDFOption) error {
 	wd, _ := os.Getwd()
 	templateDir := filepath.Join(wd, "templates")
 
-	pdfGen, err := generator.NewPDFGenerator(outputDir, templateDir, theme)
+	fontDir := filepath.Join(wd, "assets", "fonts")
+
+	opts = append([]generator.PDFOption{generator.WithFontDir(fontDir)}, opts...)
+	pdfGen, err := generator.NewPDFGenerator(outputDir, templateDir, theme, opts...)
 	if err != nil {
 		return fmt.Errorf("create PDF generator: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -26,12 +27,16 @@ func TestDetectLanguages(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(langDir, "fr"), 0755); err != nil {
 		t.Fatalf("Failed to create fr dir: %v", err)
 	}
+	if err := os.MkdirAll(filepath.Join(langDir, "es", "posts"), 0755); err != nil {
+		t.Fatalf("Failed to create posts dir: %v", err)
+	}
 
 	t.Run("Auto-detect all languages", func(t *testing.T) {
 		langs := detectLanguages(tempDir, "")
 		assert.Contains(t, langs, "en")
 		assert.Contains(t, langs, "es")
 		assert.Contains(t, langs, "fr")
+		assert.NotContains(t, langs, "posts", "directories inside a language are not languages")
 	})
 
 	t.Run("Specific language only", func(t *testing.T) {
@@ -101,3 +106,40 @@ rows:
 		assert.FileExists(t, filepath.Join(outputDir, "assets", "files", "resume-es.pdf"))
 	})
 }
+
+func TestPdfTimestamp(t *testing.T) {
+	t.Run("Configured timestamp", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+		ts, err := pdfTimestamp(t.TempDir(), true, "2024-05-06")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC), ts)
+
+		ts, err = pdfTimestamp(t.TempDir(), false, "2024-05-06T10:00:00+02:00")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.May, 6, 8, 0, 0, 0, time.UTC), ts)
+
+		_, err = pdfTimestamp(t.TempDir(), false, "yesterday")
+		assert.Error(t, err)
+	})
+
+	t.Run("SOURCE_DATE_EPOCH", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+		ts, err := pdfTimestamp(t.TempDir(), false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), ts)
+
+		t.Setenv("SOURCE_DATE_EPOCH", "soon")
+		_, err = pdfTimestamp(t.TempDir(), false, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Current time unless reproducible", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "")
+		ts, err := pdfTimestamp(t.TempDir(), false, "")
+		assert.NoError(t, err)
+		assert.True(t, ts.IsZero())
+
+		_, err = pdfTimestamp(t.TempDir(), true, "")
+		assert.Error(t, err, "a data directory outside git has no commit time")
+	})
+}
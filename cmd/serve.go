@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/odinnordico/odinnordico.github.io/internal/generator"
 	"github.com/odinnordico/odinnordico.github.io/internal/logger"
 	"github.com/odinnordico/odinnordico.github.io/internal/utils"
 )
@@ -35,9 +36,13 @@ var ServeCmd = &cobra.Command{
 		host := viper.GetString("host")
 		watch := viper.GetBool("watch")
 		theme := viper.GetString("theme")
+		options, err := themeOptions()
+		if err != nil {
+			return err
+		}
 
 		// Create regeneration function
-		regenerateWebsite := createRegenerationFunc(dataDir, outputDir, utils.DefaultLang, theme)
+		regenerateWebsite := createRegenerationFunc(dataDir, outputDir, utils.DefaultLang, theme, options, viper.GetBool("drafts"))
 
 		// Initial generation if needed
 		if err := ensureWebsiteExists(outputDir, watch, regenerateWebsite); err != nil {
@@ -61,15 +66,18 @@ func init() {
 	ServeCmd.Flags().String("host", defaultHost, "host to serve on")
 	ServeCmd.Flags().Bool("watch", false, "enable live reloading when files change")
 	ServeCmd.Flags().String("theme", "default", "website theme to use")
+	ServeCmd.Flags().Bool("drafts", false, "publish draft blog posts")
 
 	viper.BindPFlag("port", ServeCmd.Flags().Lookup("port"))
 	viper.BindPFlag("host", ServeCmd.Flags().Lookup("host"))
 	viper.BindPFlag("watch", ServeCmd.Flags().Lookup("watch"))
 	viper.BindPFlag("theme", ServeCmd.Flags().Lookup("theme"))
+	viper.BindPFlag("drafts", ServeCmd.Flags().Lookup("drafts"))
 }
 
-// createRegenerationFunc returns a function that regenerates the website and PDF.
-func createRegenerationFunc(dataDir, outputDir, lang, theme string) func() error {
+// createRegenerationFunc returns a function that regenerates the website and PDF with the
+// configured theme options, publishing draft posts when drafts is set.
+func createRegenerationFunc(dataDir, outputDir, lang, theme string, options map[string]any, drafts bool) func() error {
 	return func() error {
 		logger.Logger().Info("Regenerating website...")
 
@@ -78,18 +86,27 @@ func createRegenerationFunc(dataDir, outputDir, lang, theme string) func() error
 			return fmt.Errorf("directory validation failed: %w", err)
 		}
 
+		// Validate resume data
+		if err := ValidateData(dataDir); err != nil {
+			return err
+		}
+		if err := validateThemeOptions(theme, options); err != nil {
+			return err
+		}
+
 		// Ensure output directory exists
 		if err := os.MkdirAll(outputDir, defaultFilePermission); err != nil {
 			return fmt.Errorf("create output directory: %w", err)
 		}
 
 		// Generate website for all languages
-		if err := GenerateMultiLanguageWebsite(dataDir, outputDir, lang, theme); err != nil {
+		if err := GenerateMultiLanguageWebsite(dataDir, outputDir, lang, theme,
+			generator.WithDataExports(true), generator.WithWebsiteThemeOptions(options), generator.WithDrafts(drafts)); err != nil {
 			return fmt.Errorf("generate website: %w", err)
 		}
 
 		// Generate PDF
-		if err := GenerateMultiLanguagePdf(dataDir, outputDir, lang, theme); err != nil {
+		if err := GenerateMultiLanguagePdf(dataDir, outputDir, lang, theme, generator.WithPDFThemeOptions(options)); err != nil {
 			return fmt.Errorf("generate PDF: %w", err)
 		}
 
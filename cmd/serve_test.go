@@ -64,7 +64,7 @@ func TestCreateRegenerationFunc(t *testing.T) {
 	// This is a complex function that calls multiple other functions
 	// We'll test that it returns a callable function
 	t.Run("Returns a function", func(t *testing.T) {
-		fn := createRegenerationFunc("data", "output", "en", "default")
+		fn := createRegenerationFunc("data", "output", "en", "default", nil, false)
 		assert.NotNil(t, fn)
 		// We don't call it because it would require full setup
 		// The actual generation logic is tested in other tests
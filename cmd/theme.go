@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/generator"
+)
+
+// themeOptions returns the configured values of the theme options: the theme-options mapping of
+// the config file, overridden by the name=value pairs of --theme-opt.
+func themeOptions() (map[string]any, error) {
+	return parseThemeOptions(viper.GetStringMap("theme-options"), viper.GetStringSlice("theme-opt"))
+}
+
+// validateThemeOptions checks the theme options against the options the theme declares in the
+// templates directory of the working directory.
+func validateThemeOptions(theme string, options map[string]any) error {
+	wd, _ := os.Getwd()
+	return generator.ValidateThemeOptions(filepath.Join(wd, "templates"), theme, options)
+}
+
+// parseThemeOptions merges name=value pairs into the configured theme options. Values of pairs
+// stay strings, which the theme converts to the type of each option.
+func parseThemeOptions(configured map[string]any, pairs []string) (map[string]any, error) {
+	values := maps.Clone(configured)
+	if values == nil {
+		values = make(map[string]any)
+	}
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid theme option %q: use name=value", pair)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
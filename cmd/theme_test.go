@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseThemeOptions(t *testing.T) {
+	configured := map[string]any{"accent_color": "#336699", "show_photo": true}
+
+	values, err := parseThemeOptions(configured, []string{"show_photo=false", "section_order=skills,experience", "date_format=Jan 2, 2006"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"accent_color":  "#336699",
+		"show_photo":    "false",
+		"section_order": "skills,experience",
+		"date_format":   "Jan 2, 2006",
+	}, values)
+	assert.Equal(t, true, configured["show_photo"], "the configured values are not modified")
+
+	values, err = parseThemeOptions(nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = parseThemeOptions(nil, []string{"show_photo"})
+	assert.Error(t, err)
+	_, err = parseThemeOptions(nil, []string{"=true"})
+	assert.Error(t, err)
+}
+
+func TestValidateThemeOptions(t *testing.T) {
+	tempDir := t.TempDir()
+	themeDir := filepath.Join(tempDir, "templates", "default")
+	require.NoError(t, os.MkdirAll(themeDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(themeDir, "theme.yaml"), []byte("options:\n  show_photo:\n    type: bool\n"), 0644))
+	t.Chdir(tempDir)
+
+	assert.NoError(t, validateThemeOptions("default", map[string]any{"show_photo": "false"}))
+	err := validateThemeOptions("default", map[string]any{"show_photo": "maybe"})
+	assert.ErrorContains(t, err, "show_photo", "the error names the option")
+	assert.Error(t, validateThemeOptions("default", map[string]any{"unknown": "1"}))
+}
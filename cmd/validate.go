@@ -0,0 +1,62 @@
+// Package cmd provides command-line interface commands for the odinnordico.github.io application.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/loader"
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+	"github.com/odinnordico/odinnordico.github.io/internal/utils"
+)
+
+// ValidateCmd represents the validate command for checking YAML resume data.
+var ValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate YAML resume data",
+	Long: `Validate checks the base data files and every data/lang/<lang> overlay for unknown keys,
+missing required fields, out-of-range values, malformed URLs and end dates before start dates.
+Each problem is reported with its data file, line and column.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataDir := viper.GetString("data-dir")
+
+		if err := utils.ValidateDirectories(dataDir); err != nil {
+			return fmt.Errorf("directory validation failed: %w", err)
+		}
+
+		issues, err := loader.ValidateResumeData(dataDir)
+		if err != nil {
+			return fmt.Errorf("validate resume data: %w", err)
+		}
+
+		for _, issue := range issues {
+			fmt.Fprintln(cmd.OutOrStdout(), issue)
+		}
+		if len(issues) > 0 {
+			return fmt.Errorf("found %d validation issue(s) in %s", len(issues), dataDir)
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), "✅ Resume data is valid")
+		return nil
+	},
+}
+
+// ValidateData validates the resume data in dataDir and logs every issue found.
+// It returns an error if any issue is found, so generation can stop before producing broken output.
+func ValidateData(dataDir string) error {
+	issues, err := loader.ValidateResumeData(dataDir)
+	if err != nil {
+		return fmt.Errorf("validate resume data: %w", err)
+	}
+
+	for _, issue := range issues {
+		logger.Logger().Error("Invalid resume data", "issue", issue.String())
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d validation issue(s) in %s, run 'validate' for details", len(issues), dataDir)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_validate_data")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	basicPath := filepath.Join(tempDir, "basic.yml")
+
+	t.Run("Valid data", func(t *testing.T) {
+		if err := os.WriteFile(basicPath, []byte("name: Test User\n"), 0644); err != nil {
+			t.Fatalf("Failed to create data file: %v", err)
+		}
+		assert.NoError(t, ValidateData(tempDir))
+	})
+
+	t.Run("Invalid data", func(t *testing.T) {
+		if err := os.WriteFile(basicPath, []byte("nmae: Test User\n"), 0644); err != nil {
+			t.Fatalf("Failed to create data file: %v", err)
+		}
+		err := ValidateData(tempDir)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "2 validation issue(s)")
+	})
+}
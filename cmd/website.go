@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -25,6 +26,12 @@ var WebsiteCmd = &cobra.Command{
 		dataDir := viper.GetString("data-dir")
 		outputDir := viper.GetString("output-dir")
 		theme := viper.GetString("theme")
+		exportData := viper.GetBool("export-data")
+		drafts := viper.GetBool("drafts")
+		options, err := themeOptions()
+		if err != nil {
+			return err
+		}
 
 		logger.Logger().Info("Starting website generation...")
 		logger.Logger().Info("Data directory", "dataDir", dataDir)
@@ -36,13 +43,22 @@ var WebsiteCmd = &cobra.Command{
 			return fmt.Errorf("directory validation failed: %w", err)
 		}
 
+		// Validate resume data before generating anything
+		if err := ValidateData(dataDir); err != nil {
+			return err
+		}
+		if err := validateThemeOptions(theme, options); err != nil {
+			return err
+		}
+
 		// Ensure output directory exists
 		if err := os.MkdirAll(outputDir, 0755); err != nil {
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 
 		// Generate website for all languages
-		if err := GenerateMultiLanguageWebsite(dataDir, outputDir, utils.DefaultLang, theme); err != nil {
+		if err := GenerateMultiLanguageWebsite(dataDir, outputDir, utils.DefaultLang, theme,
+			generator.WithDataExports(exportData), generator.WithWebsiteThemeOptions(options), generator.WithDrafts(drafts)); err != nil {
 			return err
 		}
 
@@ -53,27 +69,19 @@ var WebsiteCmd = &cobra.Command{
 
 func init() {
 	WebsiteCmd.Flags().String("theme", "default", "website theme to use")
+	WebsiteCmd.Flags().Bool("export-data", true, "publish resume.json and data.json next to each index.html")
+	WebsiteCmd.Flags().Bool("drafts", false, "publish draft blog posts")
 	viper.BindPFlag("theme", WebsiteCmd.Flags().Lookup("theme"))
+	viper.BindPFlag("export-data", WebsiteCmd.Flags().Lookup("export-data"))
+	viper.BindPFlag("drafts", WebsiteCmd.Flags().Lookup("drafts"))
 }
 
 // GenerateMultiLanguageWebsite generates websites for all available languages.
 // The default language (English) is placed in the root output directory,
 // while other languages are placed in subdirectories (e.g., /es for Spanish).
-func GenerateMultiLanguageWebsite(dataDir, outputDir, defaultLang, theme string) error {
-	langDirName := "lang"
-	languages := []string{defaultLang}
-	langDir := filepath.Join(dataDir, langDirName)
-
-	filepath.WalkDir(langDir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		logger.Logger().Debug("Language directory", "entry", d.Name())
-		if d.IsDir() && d.Name() != utils.DefaultLang && d.Name() != langDirName {
-			languages = append(languages, d.Name())
-		}
-		return nil
-	})
+// Each language publishes the blog posts of data/posts and of its own posts directory.
+func GenerateMultiLanguageWebsite(dataDir, outputDir, defaultLang, theme string, opts ...generator.WebsiteOption) error {
+	languages := detectLanguages(dataDir, defaultLang)
 
 	// Generate website for each language
 	for _, lang := range languages {
@@ -91,9 +99,16 @@ func GenerateMultiLanguageWebsite(dataDir, outputDir, defaultLang, theme string)
 			return fmt.Errorf("load resume data for %s: %w", lang, err)
 		}
 
+		// Load blog posts
+		posts, err := loader.LoadPosts(dataDir, lang)
+		if err != nil {
+			return fmt.Errorf("load posts for %s: %w", lang, err)
+		}
+		langOpts := append(slices.Clip(opts), generator.WithPosts(posts))
+
 		// Generate static website (only copy assets for default language)
 		copyAssets := lang == utils.DefaultLang
-		if err := GenerateWebsite(data, localizedOutputDir, lang, theme, copyAssets); err != nil {
+		if err := GenerateWebsite(data, localizedOutputDir, lang, theme, copyAssets, langOpts...); err != nil {
 			return fmt.Errorf("generate website for %s: %w", lang, err)
 		}
 	}
@@ -102,12 +117,12 @@ func GenerateMultiLanguageWebsite(dataDir, outputDir, defaultLang, theme string)
 }
 
 // GenerateWebsite generates a static website for a single language.
-func GenerateWebsite(data *models.ResumeData, outputDir, lang, theme string, copyAssets bool) error {
+func GenerateWebsite(data *models.ResumeData, outputDir, lang, theme string, copyAssets bool, opts ...generator.WebsiteOption) error {
 	wd, _ := os.Getwd()
 	templatesDir := filepath.Join(wd, "templates")
 	assetsDir := filepath.Join(wd, "assets")
 
-	websiteGen := generator.NewWebsiteGenerator(templatesDir, theme, assetsDir)
+	websiteGen := generator.NewWebsiteGenerator(templatesDir, theme, assetsDir, opts...)
 
 	return websiteGen.Generate(data, outputDir, lang, copyAssets)
 }
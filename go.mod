@@ -3,13 +3,14 @@ module github.com/odinnordico/odinnordico.github.io
 go 1.25
 
 require (
-	dario.cat/mergo v1.0.2
 	github.com/fsnotify/fsnotify v1.8.0
 	github.com/grafana/gofpdf v0.0.0-20251124125851-b99f3620dfd4
 	github.com/open2b/scriggo v0.60.0
 	github.com/spf13/cobra v1.8.0
 	github.com/spf13/viper v1.18.2
 	github.com/stretchr/testify v1.8.4
+	github.com/yuin/goldmark v1.8.6
+	golang.org/x/image v0.33.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -32,6 +33,6 @@ require (
 	go.uber.org/multierr v1.9.0 // indirect
 	golang.org/x/exp v0.0.0-20230905200255-921286631fa9 // indirect
 	golang.org/x/sys v0.30.0 // indirect
-	golang.org/x/text v0.16.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 )
@@ -0,0 +1,262 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open2b/scriggo"
+	"github.com/open2b/scriggo/native"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+const (
+	// blogDir is the directory of the blog in the output directory of each language.
+	blogDir = "blog"
+	// postTemplateFile is the template of the page of a post in the directory of a theme.
+	postTemplateFile = "blog/post.html"
+	// postListTemplateFile is the template of the pages of the post index in the directory of a theme.
+	postListTemplateFile = "blog/list.html"
+	// feedFile is the Atom feed of the posts in the blog directory.
+	feedFile = "atom.xml"
+	// postsPerPageOption is the theme option with the number of posts of each index page.
+	postsPerPageOption = "posts_per_page"
+	// defaultPostsPerPage is the number of posts of each index page of themes without the option.
+	defaultPostsPerPage = 10
+)
+
+// BlogPost is a post of the blog as website templates see it.
+type BlogPost struct {
+	models.Post
+	Content native.HTML // The Markdown body rendered to HTML
+	URL     string      // Path of the page of the post
+}
+
+// BlogPage is a page of the index of the blog.
+type BlogPage struct {
+	Posts   []*BlogPost
+	Number  int    // Number of the page, from 1
+	Total   int    // Number of pages
+	PrevURL string // Path of the previous page, or "" on the first page
+	NextURL string // Path of the next page, or "" on the last page
+}
+
+// blog holds the published posts of a language, and the post or index page being rendered,
+// which templates read from the Posts, Post and BlogPage globals.
+type blog struct {
+	posts   []*BlogPost
+	perPage int
+	post    *BlogPost
+	page    *BlogPage
+}
+
+// markdown renders the body of posts with GitHub Flavored Markdown. Raw HTML is kept, as posts
+// are written by the owner of the site like the templates are.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// newBlog renders the posts to HTML, leaving drafts out unless they are enabled. Posts keep
+// their order, newest first. Index pages have the number of posts of the posts_per_page theme
+// option, when the theme declares it, which must be at least 1.
+func (wg *WebsiteGenerator) newBlog(home string, options ThemeOptions) (*blog, error) {
+	b := &blog{perPage: defaultPostsPerPage}
+	if n, ok := options[postsPerPageOption].(int); ok {
+		if n < 1 {
+			return nil, fmt.Errorf("theme option %s: want at least 1 post per page, got %d", postsPerPageOption, n)
+		}
+		b.perPage = n
+	}
+	for _, post := range wg.posts {
+		if post.Draft && !wg.drafts {
+			continue
+		}
+		var content bytes.Buffer
+		if err := markdown.Convert([]byte(post.Body), &content); err != nil {
+			return nil, fmt.Errorf("render post %s: %w", post.Slug, err)
+		}
+		b.posts = append(b.posts, &BlogPost{
+			Post:    post,
+			Content: native.HTML(content.String()),
+			URL:     home + blogDir + "/" + post.Slug + "/",
+		})
+	}
+	return b, nil
+}
+
+// generate renders a page for each post, the pages of the post index and the Atom feed of the
+// posts. Themes render posts with blog/post.html and the index with blog/list.html.
+func (b *blog) generate(fsys fs.FS, opts *scriggo.BuildOptions, data *models.ResumeData, outputDir, home string) error {
+	postTmpl, err := scriggo.BuildTemplate(fsys, postTemplateFile, opts)
+	if err != nil {
+		return fmt.Errorf("failed to build template %s: %w", postTemplateFile, err)
+	}
+	listTmpl, err := scriggo.BuildTemplate(fsys, postListTemplateFile, opts)
+	if err != nil {
+		return fmt.Errorf("failed to build template %s: %w", postListTemplateFile, err)
+	}
+
+	for _, post := range b.posts {
+		b.post = post
+		if err := runPage(postTmpl, filepath.Join(outputDir, blogDir, post.Slug, "index.html")); err != nil {
+			return fmt.Errorf("post %s: %w", post.Slug, err)
+		}
+	}
+	b.post = nil
+
+	blogPath := home + blogDir + "/"
+	for _, page := range paginate(b.posts, b.perPage, blogPath) {
+		b.page = page
+		outputPath := filepath.Join(outputDir, blogDir, "index.html")
+		if page.Number > 1 {
+			outputPath = filepath.Join(outputDir, blogDir, "page", strconv.Itoa(page.Number), "index.html")
+		}
+		if err := runPage(listTmpl, outputPath); err != nil {
+			return fmt.Errorf("post index page %d: %w", page.Number, err)
+		}
+	}
+	b.page = nil
+
+	return writeFeed(data, b.posts, filepath.Join(outputDir, blogDir, feedFile), blogPath)
+}
+
+// paginate splits the posts into index pages of perPage posts. The first page is the blog path
+// and the others are under page/<number>/ of it.
+func paginate(posts []*BlogPost, perPage int, blogPath string) []*BlogPage {
+	total := max(1, (len(posts)+perPage-1)/perPage)
+	pages := make([]*BlogPage, total)
+	for i := range pages {
+		pages[i] = &BlogPage{
+			Posts:  posts[i*perPage : min(len(posts), (i+1)*perPage)],
+			Number: i + 1,
+			Total:  total,
+		}
+	}
+	url := func(number int) string {
+		if number == 1 {
+			return blogPath
+		}
+		return blogPath + "page/" + strconv.Itoa(number) + "/"
+	}
+	for i, page := range pages {
+		if i > 0 {
+			page.PrevURL = url(i)
+		}
+		if i < total-1 {
+			page.NextURL = url(i + 2)
+		}
+	}
+	return pages
+}
+
+// atomFeed is an Atom feed (RFC 4287).
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomLink is a link of an Atom feed or entry.
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// atomPerson is the author of an Atom feed.
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+// atomEntry is a post in an Atom feed.
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+// atomCategory is a tag of a post in an Atom feed.
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// atomContent is the HTML content of a post in an Atom feed.
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// writeFeed writes the Atom feed of the posts. Atom requires absolute ids, so the basic data
+// must have the absolute URL of the website when there are posts. The feed is updated at the
+// date of its newest post, so unchanged posts produce the same feed.
+func writeFeed(data *models.ResumeData, posts []*BlogPost, outputPath, blogPath string) error {
+	site := strings.TrimSuffix(data.Basic.Website, "/")
+	if u, err := url.Parse(site); len(posts) > 0 && (err != nil || !u.IsAbs() || u.Host == "") {
+		return fmt.Errorf("the blog feed needs the absolute URL of the website in basic.website, got %q", data.Basic.Website)
+	}
+	feed := atomFeed{
+		Title: data.Basic.Name,
+		ID:    site + blogPath,
+		Links: []atomLink{
+			{Href: site + blogPath + feedFile, Rel: "self", Type: "application/atom+xml"},
+			{Href: site + blogPath, Rel: "alternate", Type: "text/html"},
+		},
+		Author: atomPerson{Name: data.Basic.Name},
+	}
+	for _, post := range posts {
+		date := post.Date.UTC().Format(time.RFC3339)
+		if feed.Updated == "" || date > feed.Updated {
+			feed.Updated = date
+		}
+		entry := atomEntry{
+			Title:     post.Title,
+			ID:        site + post.URL,
+			Link:      atomLink{Href: site + post.URL, Rel: "alternate", Type: "text/html"},
+			Published: date,
+			Updated:   date,
+			Content:   atomContent{Type: "html", Body: string(post.Content)},
+		}
+		for _, tag := range post.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	if feed.Updated == "" {
+		feed.Updated = time.Time{}.Format(time.RFC3339)
+	}
+
+	content, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode feed: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create feed directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, append([]byte(xml.Header), append(content, '\n')...), 0644); err != nil {
+		return fmt.Errorf("failed to write feed: %w", err)
+	}
+
+	logger.Logger().Info("Generated feed", "outputPath", outputPath)
+	return nil
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestWebsiteGenerator_GenerateBlog(t *testing.T) {
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	writeTheme(t, templatesDir, "default", map[string]string{
+		"theme.yaml":      "options:\n  posts_per_page:\n    type: int\n    default: 2\n",
+		"index.html.tmpl": `{{ len(Posts) }} posts`,
+	})
+	writeTheme(t, templatesDir, filepath.Join("default", "blog"), map[string]string{
+		"post.html": `<h1>{{ Post.Title }}</h1>{{ Post.Content }}`,
+		"list.html": `{{ BlogPage.Number }}/{{ BlogPage.Total }}:{% for _, p := range BlogPage.Posts %} {{ p.URL }}{% end %}|{{ BlogPage.PrevURL }}|{{ BlogPage.NextURL }}`,
+	})
+	data := &models.ResumeData{Basic: models.BasicData{Name: "John Doe", Website: "https://example.com/"}}
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	posts := []models.Post{
+		{Slug: "draft", Title: "Draft", Date: day(4), Draft: true, Body: "Soon"},
+		{Slug: "third", Title: "Third", Date: day(3), Body: "*Three*"},
+		{Slug: "second", Title: "Second", Date: day(2), Tags: []string{"go"}, Body: "Two"},
+		{Slug: "first", Title: "First", Date: day(1), Body: "<b>One</b>"},
+	}
+
+	t.Run("Published posts", func(t *testing.T) {
+		outputDir := filepath.Join(tempDir, "published")
+		wg := NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"), WithPosts(posts))
+		require.NoError(t, wg.Generate(data, outputDir, "es", false))
+
+		files := map[string]string{
+			"index.html": "3 posts",
+			filepath.Join("blog", "third", "index.html"):     "<h1>Third</h1><p><em>Three</em></p>\n",
+			filepath.Join("blog", "first", "index.html"):     "<h1>First</h1><p><b>One</b></p>\n",
+			filepath.Join("blog", "index.html"):              "1/2: /es/blog/third/ /es/blog/second/||/es/blog/page/2/",
+			filepath.Join("blog", "page", "2", "index.html"): "2/2: /es/blog/first/|/es/blog/|",
+		}
+		for name, want := range files {
+			content, err := os.ReadFile(filepath.Join(outputDir, name))
+			if assert.NoError(t, err, name) {
+				assert.Equal(t, want, string(content), name)
+			}
+		}
+		assert.NoDirExists(t, filepath.Join(outputDir, "blog", "draft"), "drafts are not published")
+
+		content, err := os.ReadFile(filepath.Join(outputDir, "blog", feedFile))
+		require.NoError(t, err)
+		var feed atomFeed
+		require.NoError(t, xml.Unmarshal(content, &feed))
+		assert.Equal(t, "John Doe", feed.Title)
+		assert.Equal(t, "https://example.com/es/blog/", feed.ID)
+		assert.Equal(t, "2024-01-03T00:00:00Z", feed.Updated)
+		require.Len(t, feed.Entries, 3)
+		assert.Equal(t, "https://example.com/es/blog/second/", feed.Entries[1].Link.Href)
+		assert.Equal(t, []atomCategory{{Term: "go"}}, feed.Entries[1].Categories)
+		assert.Equal(t, "<p>Two</p>\n", feed.Entries[1].Content.Body)
+	})
+
+	t.Run("Drafts", func(t *testing.T) {
+		outputDir := filepath.Join(tempDir, "drafts")
+		wg := NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"), WithPosts(posts), WithDrafts(true))
+		require.NoError(t, wg.Generate(data, outputDir, "en", false))
+		assert.FileExists(t, filepath.Join(outputDir, "blog", "draft", "index.html"))
+		assert.FileExists(t, filepath.Join(outputDir, "blog", "page", "2", "index.html"))
+	})
+
+	t.Run("Feed without the website URL", func(t *testing.T) {
+		wg := NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"), WithPosts(posts))
+		for _, website := range []string{"", "example.com", "/blog"} {
+			noSite := &models.ResumeData{Basic: models.BasicData{Name: "John Doe", Website: website}}
+			err := wg.Generate(noSite, filepath.Join(tempDir, "nosite"), "en", false)
+			assert.ErrorContains(t, err, "basic.website", website)
+		}
+	})
+
+	t.Run("Posts per page below 1", func(t *testing.T) {
+		wg := NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"), WithPosts(posts),
+			WithWebsiteThemeOptions(map[string]any{postsPerPageOption: 0}))
+		assert.ErrorContains(t, wg.Generate(data, filepath.Join(tempDir, "zero"), "en", false), postsPerPageOption)
+	})
+
+	t.Run("No posts", func(t *testing.T) {
+		outputDir := filepath.Join(tempDir, "empty")
+		wg := NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"), WithPosts(posts[:1]))
+		require.NoError(t, wg.Generate(data, outputDir, "en", false))
+		assert.FileExists(t, filepath.Join(outputDir, "index.html"))
+		assert.NoDirExists(t, filepath.Join(outputDir, "blog"))
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	posts := make([]*BlogPost, 5)
+	pages := paginate(posts, 2, "/blog/")
+	require.Len(t, pages, 3)
+	assert.Len(t, pages[0].Posts, 2)
+	assert.Len(t, pages[2].Posts, 1)
+	assert.Equal(t, []string{"", "/blog/"}, []string{pages[0].PrevURL, pages[1].PrevURL})
+	assert.Equal(t, []string{"/blog/page/2/", "/blog/page/3/", ""}, []string{pages[0].NextURL, pages[1].NextURL, pages[2].NextURL})
+	assert.Equal(t, "/blog/page/2/", pages[2].PrevURL)
+
+	pages = paginate(nil, 2, "/blog/")
+	require.Len(t, pages, 1, "an empty blog has one index page")
+	assert.Equal(t, 1, pages[0].Total)
+}
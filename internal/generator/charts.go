@@ -0,0 +1,271 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/grafana/gofpdf"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+const (
+	// defaultRatingMax is the highest level of ratings and radar charts, the range of skill levels.
+	defaultRatingMax = 10.0
+	// defaultDotCount is the number of dots of a dot rating.
+	defaultDotCount = 5
+	// defaultBarSize is the height of a bar rating in millimeters.
+	defaultBarSize = 2.0
+	// defaultDotSize is the diameter of the dots of a dot rating in millimeters.
+	defaultDotSize = 2.5
+	// defaultRadarRings is the number of grid rings of a radar chart.
+	defaultRadarRings = 5
+	// radarLabelGap is the space between a radar chart and its labels in millimeters.
+	radarLabelGap = 1.5
+)
+
+var (
+	defaultRatingColor = Color{Red: 80, Green: 80, Blue: 80}
+	defaultTrackColor  = Color{Red: 225, Green: 225, Blue: 225}
+)
+
+// checkCharts verifies the kind and range of ratings and radar charts.
+func (t *Template) checkCharts() error {
+	var err error
+	t.walkCols(func(path string, col *Col) bool {
+		if r := col.Rating; r != nil {
+			switch strings.ToLower(r.Kind) {
+			case "", "bar", "dots":
+			default:
+				err = fmt.Errorf("%s: rating kind must be bar or dots, got %q", path, r.Kind)
+			}
+			if r.Max < 0 || r.Count < 0 || r.Size < 0 || r.Gap < 0 || r.Radius < 0 {
+				err = fmt.Errorf("%s: rating sizes cannot be negative", path)
+			}
+		}
+		if r := col.Radar; r != nil && (r.Max < 0 || r.Rings < 0 || r.Size < 0) {
+			err = fmt.Errorf("%s: radar sizes cannot be negative", path)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// topSkills returns the n skills with the highest levels, keeping the order of the data between
+// skills of the same level. All skills are returned when n is not positive.
+func topSkills(n int, skills []models.Skill) []models.Skill {
+	top := make([]models.Skill, len(skills))
+	copy(top, skills)
+	sort.SliceStable(top, func(i, j int) bool { return top[i].Level > top[j].Level })
+	if n > 0 && n < len(top) {
+		top = top[:n]
+	}
+	return top
+}
+
+// size returns the height of a rating.
+func (p *RatingProp) size() float64 {
+	if p.Size > 0 {
+		return p.Size
+	}
+	if strings.EqualFold(p.Kind, "dots") {
+		return defaultDotSize
+	}
+	return defaultBarSize
+}
+
+// level returns the value of a rating as a fraction of its maximum, between 0 and 1.
+func level(value, maxValue float64) float64 {
+	if maxValue <= 0 {
+		maxValue = defaultRatingMax
+	}
+	return math.Max(0, math.Min(1, value/maxValue))
+}
+
+// chartColor returns the color of a chart, falling back to the color of its named style and
+// then to a default.
+func (pg *PDFGenerator) chartColor(c *Color, style string, fallback Color) Color {
+	if c != nil {
+		return *c
+	}
+	if named := pg.styles[style].Color; style != "" && named != nil {
+		return *named
+	}
+	return fallback
+}
+
+// renderRating renders a rating column centered vertically in the row.
+func (pg *PDFGenerator) renderRating(pdf *gofpdf.Fpdf, width, rowHeight float64, p *RatingProp) {
+	x, y := pdf.GetXY()
+	size := p.size()
+	y += max(0, rowHeight-size) / 2
+
+	fill := pg.chartColor(p.Color, p.Style, defaultRatingColor)
+	track := defaultTrackColor
+	if p.Track != nil {
+		track = *p.Track
+	}
+	filled := level(p.Value, p.Max)
+
+	if strings.EqualFold(p.Kind, "dots") {
+		count := p.Count
+		if count == 0 {
+			count = defaultDotCount
+		}
+		gap := p.Gap
+		if gap == 0 {
+			gap = size / 2
+		}
+		// Shrink the dots when they do not fit in the column
+		size = max(0, min(size, (width-float64(count-1)*gap)/float64(count)))
+		on := int(math.Round(filled * float64(count)))
+
+		for i := 0; i < count; i++ {
+			if i < on {
+				setFillColor(pdf, &fill)
+			} else {
+				setFillColor(pdf, &track)
+			}
+			pdf.Circle(x+float64(i)*(size+gap)+size/2, y+size/2, size/2, "F")
+		}
+	} else {
+		radius := min(p.Radius, size/2)
+		setFillColor(pdf, &track)
+		pdf.RoundedRect(x, y, width, size, radius, "1234", "F")
+		if w := width * filled; w > 0 {
+			setFillColor(pdf, &fill)
+			pdf.RoundedRect(x, y, w, size, min(radius, w/2), "1234", "F")
+		}
+	}
+
+	pdf.SetXY(x, y+size)
+}
+
+// radarLayout is the geometry of a radar chart in a column.
+type radarLayout struct {
+	radius      float64
+	labelStyle  TextStyle
+	labelHeight float64
+}
+
+// layoutRadar sizes a radar chart for a column of the given width. Without a size, the chart
+// takes 55% of the width, leaving room for the labels on its sides.
+func (pg *PDFGenerator) layoutRadar(width float64, p *RadarProp) radarLayout {
+	label := p.Label
+	if label == nil {
+		label = &TextProp{}
+	}
+	style := resolveTextStyle(pg.styles, label)
+
+	size := p.Size
+	if size == 0 || size > width {
+		size = width * 0.55
+	}
+	return radarLayout{radius: size / 2, labelStyle: style, labelHeight: style.lineHeight()}
+}
+
+// height returns the height of a radar chart including the labels above and below it.
+func (l radarLayout) height() float64 {
+	return 2*l.radius + 2*(l.labelHeight+radarLabelGap)
+}
+
+// renderRadar renders a radar chart column centered in the column: grid rings and spokes, the
+// translucent area of the levels with its outline, and the labels around the chart.
+func (pg *PDFGenerator) renderRadar(pdf *gofpdf.Fpdf, width float64, p *RadarProp) {
+	x, y := pdf.GetXY()
+	l := pg.layoutRadar(width, p)
+	cx, cy := x+width/2, y+l.labelHeight+radarLabelGap+l.radius
+	n := len(p.Items)
+	if n == 0 {
+		return
+	}
+
+	point := func(i int, r float64) gofpdf.PointType {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(n)
+		return gofpdf.PointType{X: cx + r*math.Cos(angle), Y: cy + r*math.Sin(angle)}
+	}
+
+	// Grid
+	grid := Color{Red: 210, Green: 210, Blue: 210}
+	if p.Grid != nil {
+		grid = *p.Grid
+	}
+	pdf.SetDrawColor(grid.Red, grid.Green, grid.Blue)
+	pdf.SetLineWidth(0.2)
+	rings := p.Rings
+	if rings == 0 {
+		rings = defaultRadarRings
+	}
+	for ring := 1; ring <= rings; ring++ {
+		points := make([]gofpdf.PointType, n)
+		for i := range points {
+			points[i] = point(i, l.radius*float64(ring)/float64(rings))
+		}
+		pdf.Polygon(points, "D")
+	}
+	for i := 0; i < n; i++ {
+		outer := point(i, l.radius)
+		pdf.Line(cx, cy, outer.X, outer.Y)
+	}
+
+	// Levels
+	color := pg.chartColor(p.Color, p.Style, defaultRatingColor)
+	fill := color
+	if p.Fill != nil {
+		fill = *p.Fill
+	}
+	points := make([]gofpdf.PointType, n)
+	for i, item := range p.Items {
+		points[i] = point(i, l.radius*level(item.Value, p.Max))
+	}
+	setFillColor(pdf, &fill)
+	pdf.SetAlpha(0.35, "Normal")
+	pdf.Polygon(points, "F")
+	pdf.SetAlpha(1, "Normal")
+	pdf.SetDrawColor(color.Red, color.Green, color.Blue)
+	pdf.SetLineWidth(0.4)
+	pdf.Polygon(points, "D")
+	setFillColor(pdf, &color)
+	for _, pt := range points {
+		pdf.Circle(pt.X, pt.Y, 0.6, "F")
+	}
+
+	// Labels, placed outwards from the end of their spoke
+	pg.setFont(pdf, l.labelStyle)
+	if c := l.labelStyle.Color; c != nil {
+		pdf.SetTextColor(c.Red, c.Green, c.Blue)
+	} else {
+		pdf.SetTextColor(0, 0, 0)
+	}
+	cellMargin := pdf.GetCellMargin()
+	pdf.SetCellMargin(0)
+	for i, item := range p.Items {
+		text := pg.pdfText(pg.family(l.labelStyle), item.Label)
+		w := pdf.GetStringWidth(text)
+		pt := point(i, l.radius+radarLabelGap)
+		dx, dy := pt.X-cx, pt.Y-cy
+
+		lx := pt.X - w/2
+		switch {
+		case dx > 0.1:
+			lx = pt.X
+		case dx < -0.1:
+			lx = pt.X - w
+		}
+		ly := pt.Y - l.labelHeight/2
+		switch {
+		case dy < -0.1*l.radius:
+			ly = pt.Y - l.labelHeight
+		case dy > 0.1*l.radius:
+			ly = pt.Y
+		}
+
+		pdf.SetXY(lx, ly)
+		pdf.CellFormat(w, l.labelHeight, text, "", 0, "LM", false, 0, "")
+	}
+	pdf.SetCellMargin(cellMargin)
+
+	pdf.SetXY(x, y+l.height())
+}
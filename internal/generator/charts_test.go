@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestTopSkills(t *testing.T) {
+	skills := []models.Skill{
+		{Name: "SQL", Level: 6},
+		{Name: "Go", Level: 10},
+		{Name: "Python", Level: 8},
+		{Name: "Docker", Level: 8},
+	}
+
+	names := func(skills []models.Skill) []string {
+		var names []string
+		for _, s := range skills {
+			names = append(names, s.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"Go", "Python", "Docker"}, names(topSkills(3, skills)))
+	assert.Equal(t, []string{"Go", "Python", "Docker", "SQL"}, names(topSkills(0, skills)))
+	assert.Equal(t, "SQL", skills[0].Name, "the data keeps its order")
+}
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, 0.7, level(7, 0))
+	assert.Equal(t, 0.6, level(3, 5))
+	assert.Equal(t, 1.0, level(12, 10))
+	assert.Equal(t, 0.0, level(-1, 10))
+}
+
+func TestTemplate_CheckCharts(t *testing.T) {
+	col := func(c Col) *Template {
+		c.Width = 12
+		return &Template{Rows: []Row{{Cols: []Col{c}}}}
+	}
+
+	assert.NoError(t, col(Col{Rating: &RatingProp{Kind: "dots", Value: 8}}).checkCharts())
+	assert.NoError(t, col(Col{Radar: &RadarProp{Items: []RadarItem{{Label: "Go", Value: 10}}}}).checkCharts())
+	assert.Error(t, col(Col{Rating: &RatingProp{Kind: "stars"}}).checkCharts())
+	assert.Error(t, col(Col{Rating: &RatingProp{Count: -1}}).checkCharts())
+	assert.Error(t, col(Col{Radar: &RadarProp{Size: -10}}).checkCharts())
+}
+
+func TestPDFGenerator_RenderCharts(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	require.NoError(t, err)
+
+	t.Run("Rating is centered in its row", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		text := &TextProp{Content: "Go", Size: 10}
+		cols := pg.layoutCols(pdf, pg.page.content(), []Col{
+			{Width: 4, Text: text},
+			{Width: 8, Rating: &RatingProp{Value: 10}},
+		})
+		assert.Equal(t, defaultBarSize, cols[1].height)
+
+		pg.renderRow(pdf, pg.page.content(), &Row{Cols: []Col{
+			{Width: 4, Text: text},
+			{Width: 8, Rating: &RatingProp{Kind: "dots", Value: 4, Max: 5}},
+		}})
+		assert.InDelta(t, pg.page.top(1)+resolveTextStyle(nil, text).lineHeight(), pdf.GetY(), 0.01)
+		assert.NoError(t, pdf.Error())
+	})
+
+	t.Run("Radar chart with labels", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		radar := &RadarProp{
+			Size:  40,
+			Label: &TextProp{Size: 7},
+			Items: []RadarItem{{"Go", 10}, {"Java", 9}, {"Git", 9}, {"Python", 8}, {"Docker", 8}},
+		}
+		labelHeight := resolveTextStyle(nil, radar.Label).lineHeight()
+
+		pg.renderRow(pdf, pg.page.content(), &Row{Cols: []Col{{Width: 6, Radar: radar}}})
+		assert.InDelta(t, pg.page.top(1)+40+2*(labelHeight+radarLabelGap), pdf.GetY(), 0.01)
+		assert.NoError(t, pdf.Error())
+	})
+}
@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/jsonresume"
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+	"github.com/odinnordico/odinnordico.github.io/internal/utils"
+)
+
+const (
+	// JSONResumeFile is the name of the JSON Resume export.
+	JSONResumeFile = "resume.json"
+	// DataJSONFile is the name of the canonical JSON dump of models.ResumeData.
+	DataJSONFile = "data.json"
+)
+
+// ExportGenerator writes machine-readable exports of the loaded resume data.
+type ExportGenerator struct {
+	outputDir string
+}
+
+// NewExportGenerator creates a new export generator writing into outputDir.
+func NewExportGenerator(outputDir string) *ExportGenerator {
+	return &ExportGenerator{
+		outputDir: outputDir,
+	}
+}
+
+// Generate writes the JSON Resume and canonical JSON exports for the given language.
+// The default language is written to the output directory root and other languages
+// to a subdirectory named after the language, mirroring the website layout.
+func (eg *ExportGenerator) Generate(data *models.ResumeData, lang string) error {
+	if data == nil {
+		return fmt.Errorf("resume data cannot be nil")
+	}
+
+	dir := eg.outputDir
+	if lang != utils.DefaultLang && lang != "" {
+		dir = filepath.Join(eg.outputDir, lang)
+	}
+
+	return writeDataExports(data, dir)
+}
+
+// writeDataExports writes resume.json and data.json into dir.
+func writeDataExports(data *models.ResumeData, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	exports := []struct {
+		name  string
+		value any
+	}{
+		{JSONResumeFile, jsonresume.FromResumeData(data)},
+		{DataJSONFile, data},
+	}
+
+	for _, export := range exports {
+		content, err := json.MarshalIndent(export.value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", export.name, err)
+		}
+
+		path := filepath.Join(dir, export.name)
+		if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+			return fmt.Errorf("write %s: %w", export.name, err)
+		}
+		logger.Logger().Info("Generated export", "file", path)
+	}
+
+	return nil
+}
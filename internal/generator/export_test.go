@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestExportGenerator_Generate(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_export_gen")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	eg := NewExportGenerator(tempDir)
+	data := &models.ResumeData{
+		Basic:        models.BasicData{Name: "John Doe"},
+		Professional: models.ProfessionalData{Title: "Engineer"},
+	}
+
+	t.Run("Default language", func(t *testing.T) {
+		err := eg.Generate(data, "en")
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(tempDir, JSONResumeFile))
+		assert.NoError(t, err)
+		var resume map[string]any
+		assert.NoError(t, json.Unmarshal(content, &resume))
+		assert.Equal(t, "Engineer", resume["basics"].(map[string]any)["label"])
+
+		content, err = os.ReadFile(filepath.Join(tempDir, DataJSONFile))
+		assert.NoError(t, err)
+		var dump models.ResumeData
+		assert.NoError(t, json.Unmarshal(content, &dump))
+		assert.Equal(t, *data, dump)
+	})
+
+	t.Run("Other language", func(t *testing.T) {
+		err := eg.Generate(data, "es")
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(tempDir, "es", JSONResumeFile))
+		assert.FileExists(t, filepath.Join(tempDir, "es", DataJSONFile))
+	})
+
+	t.Run("Nil data", func(t *testing.T) {
+		assert.Error(t, eg.Generate(nil, "en"))
+	})
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/gofpdf"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+const (
+	// defaultFitFontScale is the smallest scale of the font sizes of a template without fit bounds.
+	defaultFitFontScale = 0.8
+	// defaultFitLineScale is the smallest scale of the line spacing of a template without fit bounds.
+	defaultFitLineScale = 0.85
+	// defaultFitSpacingScale is the smallest scale of the vertical spacing of a template without fit bounds.
+	defaultFitSpacingScale = 0.5
+	// defaultFitSteps is the number of sizes tried down to the smallest scale.
+	defaultFitSteps = 8
+)
+
+// fitScale is the scale of the font sizes, line spacing and vertical spacing of a template.
+type fitScale struct {
+	font    float64
+	line    float64
+	spacing float64
+}
+
+// section is a run of top-level rows from a level 0 bookmark to the next, and the pages it is
+// rendered on.
+type section struct {
+	name  string
+	first int
+	last  int
+}
+
+// checkFit verifies that the fit bounds are scales between 0 and 1.
+func (t *Template) checkFit() error {
+	if t.Fit == nil {
+		return nil
+	}
+	scales := []struct {
+		name  string
+		scale float64
+	}{{"font_scale", t.Fit.FontScale}, {"line_scale", t.Fit.LineScale}, {"spacing_scale", t.Fit.SpacingScale}}
+	for _, s := range scales {
+		if s.scale < 0 || s.scale > 1 {
+			return fmt.Errorf("fit: %s must be between 0 and 1, got %g", s.name, s.scale)
+		}
+	}
+	if t.Fit.Steps < 0 {
+		return fmt.Errorf("fit: steps cannot be negative, got %d", t.Fit.Steps)
+	}
+	return nil
+}
+
+// fitBounds returns the fit bounds of the template, with defaults for the bounds it does not set.
+func (t *Template) fitBounds() Fit {
+	f := Fit{FontScale: defaultFitFontScale, LineScale: defaultFitLineScale, SpacingScale: defaultFitSpacingScale, Steps: defaultFitSteps}
+	if t.Fit == nil {
+		return f
+	}
+	if t.Fit.FontScale > 0 {
+		f.FontScale = t.Fit.FontScale
+	}
+	if t.Fit.LineScale > 0 {
+		f.LineScale = t.Fit.LineScale
+	}
+	if t.Fit.SpacingScale > 0 {
+		f.SpacingScale = t.Fit.SpacingScale
+	}
+	if t.Fit.Steps > 0 {
+		f.Steps = t.Fit.Steps
+	}
+	return f
+}
+
+// at returns the scale of the given step, from full size at step 0 to the smallest scale at the last step.
+func (f Fit) at(step int) fitScale {
+	progress := float64(step) / float64(f.Steps)
+	return fitScale{
+		font:    1 - progress*(1-f.FontScale),
+		line:    1 - progress*(1-f.LineScale),
+		spacing: 1 - progress*(1-f.SpacingScale),
+	}
+}
+
+// scale shrinks the font sizes and line spacing of the styles and columns of the template, the
+// heights of its rows and the space between list items. The default style gets the default size
+// and spacing, so text that sets neither shrinks as well.
+func (t *Template) scale(s fitScale) {
+	if t.Styles == nil {
+		t.Styles = make(map[string]TextStyle)
+	}
+	base := TextStyle{Size: defaultFontSize, Spacing: defaultLineSpacing}
+	t.Styles[defaultStyleName] = base.merge(t.Styles[defaultStyleName])
+	for name, style := range t.Styles {
+		style.Size *= s.font
+		style.Spacing *= s.line
+		t.Styles[name] = style
+	}
+
+	scaleText := func(p *TextProp) {
+		if p != nil {
+			p.Size *= s.font
+		}
+	}
+	t.walkRows(func(_ string, r *Row) bool {
+		r.Height *= s.spacing
+		for i := range r.Cols {
+			col := &r.Cols[i]
+			scaleText(col.Text)
+			if col.List != nil {
+				col.List.Size *= s.font
+				col.List.ItemSpacing = col.List.itemSpacing() * s.spacing
+			}
+			if col.Shape != nil {
+				scaleText(col.Shape.Label)
+			}
+			if col.Radar != nil {
+				scaleText(col.Radar.Label)
+			}
+		}
+		return true
+	})
+}
+
+// fit renders the template at decreasing scales until it fits in the maximum number of pages, and
+// returns the template and document that fit. When the document does not fit even at the smallest
+// scale, the error reports the sections that overflow.
+func (pg *PDFGenerator) fit(data *models.ResumeData, families map[string]fontFamily) (*Template, *gofpdf.Fpdf, error) {
+	for step := 1; ; step++ {
+		// Every step scales a fresh template, so sizes do not compound
+		t, err := pg.parseTemplate(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse template: %w", err)
+		}
+		bounds := t.fitBounds()
+		scale := bounds.at(min(step, bounds.Steps))
+		t.scale(scale)
+		pg.styles = t.Styles
+
+		pdf, err := pg.render(t, families)
+		if err != nil {
+			return nil, nil, err
+		}
+		if pdf.PageCount() <= pg.maxPages {
+			logger.Logger().Info("PDF fitted", "pages", pdf.PageCount(), "font_scale", fmt.Sprintf("%.2f", scale.font))
+			return t, pdf, nil
+		}
+		if step >= bounds.Steps {
+			return nil, nil, pg.overflowError(pdf.PageCount())
+		}
+	}
+}
+
+// overflowError reports the sections that end after the maximum number of pages.
+func (pg *PDFGenerator) overflowError(pages int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "resume needs %d pages even at the smallest scale of the theme, more than the maximum of %d; overflowing sections:", pages, pg.maxPages)
+	for _, s := range pg.sections {
+		if s.last <= pg.maxPages {
+			continue
+		}
+		if s.first == s.last {
+			fmt.Fprintf(&b, "\n  %s: page %d", s.name, s.first)
+		} else {
+			fmt.Fprintf(&b, "\n  %s: pages %d-%d", s.name, s.first, s.last)
+		}
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+// sections groups top-level rows into sections that start at every level 0 bookmark, given the
+// pages each row is rendered on. Rows before the first bookmark are named by their position.
+func sections(prefix string, rows []Row, spans []pageSpan) []section {
+	var out []section
+	for i, r := range rows {
+		if len(out) == 0 || (r.Bookmark != "" && r.BookmarkLevel == 0) {
+			name := r.Bookmark
+			if name == "" {
+				name = fmt.Sprintf("%srow %d", prefix, i+1)
+			}
+			out = append(out, section{name: name, first: spans[i].first})
+		}
+		s := &out[len(out)-1]
+		s.last = max(s.last, spans[i].last)
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestTemplate_CheckFit(t *testing.T) {
+	assert.NoError(t, (&Template{}).checkFit())
+	assert.NoError(t, (&Template{Fit: &Fit{FontScale: 0.7, LineScale: 1, Steps: 4}}).checkFit())
+	assert.Error(t, (&Template{Fit: &Fit{FontScale: 1.2}}).checkFit())
+	assert.Error(t, (&Template{Fit: &Fit{SpacingScale: -0.5}}).checkFit())
+	assert.Error(t, (&Template{Fit: &Fit{Steps: -1}}).checkFit())
+}
+
+func TestTemplate_FitBounds(t *testing.T) {
+	bounds := (&Template{Fit: &Fit{FontScale: 0.9, Steps: 2}}).fitBounds()
+	assert.Equal(t, Fit{FontScale: 0.9, LineScale: defaultFitLineScale, SpacingScale: defaultFitSpacingScale, Steps: 2}, bounds)
+
+	assert.Equal(t, fitScale{font: 1, line: 1, spacing: 1}, bounds.at(0))
+	half := bounds.at(1)
+	assert.InDelta(t, 0.95, half.font, 0.001)
+	assert.InDelta(t, 1-(1-defaultFitSpacingScale)/2, half.spacing, 0.001)
+	last := bounds.at(2)
+	assert.InDelta(t, 0.9, last.font, 0.001)
+	assert.InDelta(t, defaultFitLineScale, last.line, 0.001)
+}
+
+func TestTemplate_Scale(t *testing.T) {
+	tmpl := &Template{
+		Styles: map[string]TextStyle{"heading": {Size: 12, Weight: "bold"}},
+		Header: &Row{Height: 6, Cols: []Col{{Width: 12, Text: &TextProp{Content: "John Doe"}}}},
+		Rows: []Row{
+			{Height: 10, Cols: []Col{{Width: 12, Text: &TextProp{Content: "x", Size: 20}}}},
+			{Rows: []Row{{Cols: []Col{{Width: 12, List: &ListProp{Items: []string{"a"}, Size: 8}}}}}},
+			{Cols: []Col{{Width: 12, Shape: &ShapeProp{Label: &TextProp{Content: "Go"}}}}},
+		},
+	}
+	tmpl.scale(fitScale{font: 0.5, line: 0.8, spacing: 0.25})
+
+	assert.Equal(t, TextStyle{Size: defaultFontSize * 0.5, Spacing: defaultLineSpacing * 0.8}, tmpl.Styles[defaultStyleName],
+		"text without a size or spacing shrinks from the defaults")
+	assert.Equal(t, TextStyle{Size: 6, Weight: "bold"}, tmpl.Styles["heading"], "styles inherit the scaled default spacing")
+	assert.InDelta(t, 1.5, tmpl.Header.Height, 0.001)
+	assert.InDelta(t, 2.5, tmpl.Rows[0].Height, 0.001)
+	assert.InDelta(t, 10, tmpl.Rows[0].Cols[0].Text.Size, 0.001)
+	list := tmpl.Rows[1].Rows[0].Cols[0].List
+	assert.InDelta(t, 4, list.Size, 0.001)
+	assert.InDelta(t, defaultItemSpacing*0.25, list.ItemSpacing, 0.001)
+	assert.Zero(t, tmpl.Rows[2].Cols[0].Shape.Label.Size, "labels without a size keep the scaled style size")
+
+	style := resolveTextStyle(tmpl.Styles, &TextProp{Style: "heading"})
+	assert.InDelta(t, 6*pointsToMM*defaultLineSpacing*0.8, style.lineHeight(), 0.001)
+}
+
+func TestSections(t *testing.T) {
+	rows := []Row{
+		{},
+		{Bookmark: "Experience"},
+		{Bookmark: "Globant", BookmarkLevel: 1},
+		{},
+		{Bookmark: "Education"},
+	}
+	spans := []pageSpan{{1, 1}, {1, 1}, {1, 2}, {2, 3}, {3, 3}}
+
+	assert.Equal(t, []section{
+		{name: "row 1", first: 1, last: 1},
+		{name: "Experience", first: 1, last: 3},
+		{name: "Education", first: 3, last: 3},
+	}, sections("", rows, spans))
+}
+
+func TestPDFGenerator_GenerateMaxPages(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templateDir := filepath.Join(tempDir, "templates")
+	require.NoError(t, os.MkdirAll(filepath.Join(templateDir, "default"), 0755))
+
+	// Two sections of 14 rows of 10 mm, a little more than the 277 mm of an A4 page
+	tmplContent := `
+fit:
+  spacing_scale: SPACING_SCALE
+  steps: 4
+rows:
+  - bookmark: Experience
+    cols:
+      - width: 12
+        text:
+          content: "Experience"
+{{- range $i, $_ := .Skills}}
+  {{- if eq $i 14}}
+  - bookmark: Education
+    cols:
+      - width: 12
+        text:
+          content: "Education"
+  {{- end}}
+  - height: 10
+    cols:
+      - width: 12
+        text:
+          content: "{{.Name}}"
+{{- end}}
+`
+	data := &models.ResumeData{}
+	for i := 0; i < 28; i++ {
+		data.Skills = append(data.Skills, models.Skill{Name: strings.Repeat("x", i+1)})
+	}
+
+	generate := func(spacingScale string, opts ...PDFOption) (*PDFGenerator, error) {
+		content := strings.Replace(tmplContent, "SPACING_SCALE", spacingScale, 1)
+		require.NoError(t, os.WriteFile(filepath.Join(templateDir, "default", "resume.yaml.tmpl"), []byte(content), 0644))
+		pg, err := NewPDFGenerator(outputDir, templateDir, "default", opts...)
+		require.NoError(t, err)
+		return pg, pg.Generate(data, "en")
+	}
+
+	t.Run("Without a maximum", func(t *testing.T) {
+		pg, err := generate("0.5")
+		require.NoError(t, err)
+		assert.Equal(t, 2, pg.sections[len(pg.sections)-1].last)
+	})
+
+	t.Run("Shrinks to fit", func(t *testing.T) {
+		pg, err := generate("0.5", WithMaxPages(1))
+		require.NoError(t, err)
+		assert.Equal(t, 1, pg.sections[len(pg.sections)-1].last)
+		assert.Less(t, pg.styles[defaultStyleName].Size, float64(defaultFontSize))
+	})
+
+	t.Run("Reports the sections that overflow", func(t *testing.T) {
+		_, err := generate("1", WithMaxPages(1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resume needs 2 pages")
+		assert.Contains(t, err.Error(), "Education: pages 1-2")
+		assert.NotContains(t, err.Error(), "Experience")
+	})
+}
@@ -0,0 +1,357 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/grafana/gofpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+)
+
+const (
+	// defaultFontFamily is the family of the Go fonts embedded in the binary.
+	// It is always registered, so text renders even when no TTF files are available.
+	defaultFontFamily = "Go"
+	// preferredFontFamily is the family used instead of the embedded fonts when its TTF files
+	// are found in the font directory, matching the font of the website.
+	preferredFontFamily = "Inter"
+)
+
+// fontStyles maps the style suffix of a TTF file name to the gofpdf style string.
+var fontStyles = map[string]string{
+	"regular":    "",
+	"bold":       "B",
+	"italic":     "I",
+	"bolditalic": "BI",
+}
+
+// fontFallbacks lists, for each gofpdf style, the styles to use when a family does not provide it.
+var fontFallbacks = map[string][]string{
+	"":   {"B", "I", "BI"},
+	"B":  {"", "BI", "I"},
+	"I":  {"", "BI", "B"},
+	"BI": {"B", "I", ""},
+}
+
+// fontFamily holds the TrueType data of every style of a font family.
+type fontFamily map[string][]byte
+
+// loadFonts collects the font families available to a template: the embedded Go fonts, every TTF
+// file in fontDir, and the families the template declares in its fonts section, whose files are
+// relative to the nearest of the theme directories that has them. TTF files in fontDir are
+// grouped into families by their "<Family>-<Style>.ttf" name, where the style is Regular, Bold,
+// Italic or BoldItalic; a file without a style suffix is the regular style.
+func loadFonts(fontDir string, themeDirs []string, declared map[string]FontProp) (map[string]fontFamily, error) {
+	families := map[string]fontFamily{
+		defaultFontFamily: {
+			"":   goregular.TTF,
+			"B":  gobold.TTF,
+			"I":  goitalic.TTF,
+			"BI": gobolditalic.TTF,
+		},
+	}
+
+	found, err := loadFontDir(fontDir)
+	if err != nil {
+		return nil, err
+	}
+	for name, family := range found {
+		families[name] = family
+	}
+
+	for name, prop := range declared {
+		family := make(fontFamily)
+		for style, file := range map[string]string{"": prop.Regular, "B": prop.Bold, "I": prop.Italic, "BI": prop.BoldItalic} {
+			if file == "" {
+				continue
+			}
+			path := file
+			if !filepath.IsAbs(file) && len(themeDirs) > 0 {
+				path = filepath.Join(themeDirs[0], file)
+				if found, ok := findThemeFile(themeDirs, file); ok {
+					path = found
+				}
+			}
+			data, err := readFontFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("font %s: %w", name, err)
+			}
+			family[style] = data
+		}
+		if len(family) == 0 {
+			return nil, fmt.Errorf("font %s does not list any TTF file", name)
+		}
+		families[name] = family
+	}
+
+	return families, nil
+}
+
+// registerFonts registers every font family with the PDF as UTF-8 fonts and returns the family
+// text is rendered with when its style does not name one. Styles a family does not provide fall
+// back to the closest one it does, so bold and italic text always renders.
+func registerFonts(pdf *gofpdf.Fpdf, families map[string]fontFamily) (string, error) {
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		family := families[name]
+		for _, style := range []string{"", "B", "I", "BI"} {
+			pdf.AddUTF8FontFromBytes(name, style, family.style(style))
+		}
+		if err := pdf.Error(); err != nil {
+			return "", fmt.Errorf("register font %s: %w", name, err)
+		}
+	}
+
+	if _, ok := families[preferredFontFamily]; ok {
+		return preferredFontFamily, nil
+	}
+	logger.Logger().Info("Font family not found, using the embedded fonts", "family", preferredFontFamily, "fallback", defaultFontFamily)
+	return defaultFontFamily, nil
+}
+
+// style returns the font data of style, or of its closest available fallback.
+func (f fontFamily) style(style string) []byte {
+	if data, ok := f[style]; ok {
+		return data
+	}
+	for _, fallback := range fontFallbacks[style] {
+		if data, ok := f[fallback]; ok {
+			return data
+		}
+	}
+	return nil
+}
+
+// loadFontDir reads the TTF files in dir, grouped by family. A missing directory has no fonts.
+func loadFontDir(dir string) (map[string]fontFamily, error) {
+	families := make(map[string]fontFamily)
+	if dir == "" {
+		return families, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return families, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read font directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".ttf") {
+			continue
+		}
+
+		name, style := parseFontFileName(entry.Name())
+		path := filepath.Join(dir, entry.Name())
+		data, err := readFontFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if families[name] == nil {
+			families[name] = make(fontFamily)
+		}
+		families[name][style] = data
+		logger.Logger().Debug("Found font", "family", name, "style", style, "file", path)
+	}
+
+	return families, nil
+}
+
+// readFontFile reads a TTF file, rejecting files gofpdf cannot embed.
+func readFontFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read font file %s: %w", path, err)
+	}
+	if !isTrueType(data) {
+		return nil, fmt.Errorf("font file %s is not a TrueType font", path)
+	}
+	return data, nil
+}
+
+// isTrueType reports whether data starts with a TrueType outline signature. gofpdf only embeds
+// TrueType outlines, not the CFF outlines of OpenType fonts or compressed WOFF and WOFF2 files.
+func isTrueType(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0x00, 0x01, 0x00, 0x00}) || bytes.HasPrefix(data, []byte("true"))
+}
+
+// parseFontFileName splits a "<Family>-<Style>.ttf" file name into the family and gofpdf style.
+func parseFontFileName(fileName string) (string, string) {
+	base := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	if i := strings.LastIndex(base, "-"); i > 0 {
+		if style, ok := fontStyles[strings.ToLower(base[i+1:])]; ok {
+			return base[:i], style
+		}
+	}
+	return base, ""
+}
+
+// glyphCoverage tells which characters the font families have glyphs for, and collects the
+// characters of the text that no font could render, to report them once the PDF is generated.
+type glyphCoverage struct {
+	fonts   map[string]*sfnt.Font // Regular style of each family
+	buf     sfnt.Buffer
+	missing map[string]map[rune]bool // Characters without a glyph, by family
+	dropped map[rune]bool            // Characters outside the Basic Multilingual Plane
+}
+
+// newGlyphCoverage parses the regular style of every font family.
+func newGlyphCoverage(families map[string]fontFamily) (*glyphCoverage, error) {
+	g := &glyphCoverage{
+		fonts:   make(map[string]*sfnt.Font, len(families)),
+		missing: make(map[string]map[rune]bool),
+		dropped: make(map[rune]bool),
+	}
+	for name, family := range families {
+		f, err := sfnt.Parse(family.style(""))
+		if err != nil {
+			return nil, fmt.Errorf("parse font %s: %w", name, err)
+		}
+		g.fonts[name] = f
+	}
+	return g, nil
+}
+
+// has reports whether a family has a glyph for r. Spaces and control characters need none, and
+// families that were not parsed are trusted to have every glyph.
+func (g *glyphCoverage) has(family string, r rune) bool {
+	if g == nil || unicode.IsSpace(r) || unicode.IsControl(r) {
+		return true
+	}
+	f, ok := g.fonts[family]
+	if !ok {
+		return true
+	}
+	i, err := f.GlyphIndex(&g.buf, r)
+	return err == nil && i != 0
+}
+
+// miss records a character that renders as a missing glyph in a family.
+func (g *glyphCoverage) miss(family string, r rune) {
+	if g == nil {
+		return
+	}
+	if g.missing[family] == nil {
+		g.missing[family] = make(map[rune]bool)
+	}
+	g.missing[family][r] = true
+}
+
+// drop records a character that cannot be rendered at all.
+func (g *glyphCoverage) drop(r rune) {
+	if g != nil {
+		g.dropped[r] = true
+	}
+}
+
+// warn logs the characters that were dropped or rendered as missing glyphs.
+func (g *glyphCoverage) warn() {
+	if g == nil {
+		return
+	}
+	for _, family := range slices.Sorted(maps.Keys(g.missing)) {
+		logger.Logger().Warn("Font has no glyphs for characters, add a font with them to fallback_fonts",
+			"family", family, "characters", string(slices.Sorted(maps.Keys(g.missing[family]))))
+	}
+	if len(g.dropped) > 0 {
+		logger.Logger().Warn("Characters outside the Basic Multilingual Plane, such as emoji, cannot be rendered and are dropped",
+			"characters", string(slices.Sorted(maps.Keys(g.dropped))))
+	}
+}
+
+// textRun is a piece of text drawn in one font family.
+type textRun struct {
+	family string
+	text   string
+}
+
+// fontRuns splits text into runs of a family and, for the characters it has no glyph for, of the
+// first fallback font of the template that has one. Characters no font has stay in the family.
+// Characters outside the Basic Multilingual Plane are dropped, as pdfText does.
+func (pg *PDFGenerator) fontRuns(family, s string) []textRun {
+	var runs []textRun
+	var text strings.Builder
+	current := family
+	for _, r := range s {
+		if r > 0xFFFF {
+			pg.glyphs.drop(r)
+			continue
+		}
+		runFamily := current
+		if !unicode.IsSpace(r) {
+			runFamily = pg.glyphFamily(family, r)
+		}
+		if runFamily != current && text.Len() > 0 {
+			runs = append(runs, textRun{family: current, text: text.String()})
+			text.Reset()
+		}
+		current = runFamily
+		text.WriteRune(r)
+	}
+	if text.Len() > 0 {
+		runs = append(runs, textRun{family: current, text: text.String()})
+	}
+	return runs
+}
+
+// glyphFamily returns the family a character is drawn in: the family when it has the glyph, or
+// the first fallback font that has it.
+func (pg *PDFGenerator) glyphFamily(family string, r rune) string {
+	if pg.glyphs.has(family, r) {
+		return family
+	}
+	for _, fallback := range pg.fallbacks {
+		if pg.glyphs.has(fallback, r) {
+			return fallback
+		}
+	}
+	pg.glyphs.miss(family, r)
+	return family
+}
+
+// fallsBack reports whether text drawn in a family needs a fallback font for some characters.
+func (pg *PDFGenerator) fallsBack(family, s string) bool {
+	for _, run := range pg.fontRuns(family, s) {
+		if run.family != family {
+			return true
+		}
+	}
+	return false
+}
+
+// pdfText prepares UTF-8 text drawn in a single font family for the PDF. gofpdf encodes text as
+// 16-bit glyph identifiers, so characters outside the Basic Multilingual Plane (such as emoji)
+// cannot be rendered and are dropped. Dropped characters and characters the family has no
+// glyph for are reported once the PDF is generated.
+func (pg *PDFGenerator) pdfText(family, s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 0xFFFF {
+			pg.glyphs.drop(r)
+			return -1
+		}
+		if !pg.glyphs.has(family, r) {
+			pg.glyphs.miss(family, r)
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,247 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grafana/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestParseFontFileName(t *testing.T) {
+	tests := []struct {
+		file   string
+		family string
+		style  string
+	}{
+		{"Inter-Regular.ttf", "Inter", ""},
+		{"Inter-Bold.ttf", "Inter", "B"},
+		{"Inter-Italic.TTF", "Inter", "I"},
+		{"Inter-BoldItalic.ttf", "Inter", "BI"},
+		{"Noto-Sans-JP-Bold.ttf", "Noto-Sans-JP", "B"},
+		{"DejaVuSans.ttf", "DejaVuSans", ""},
+		{"Fira-Code.ttf", "Fira-Code", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			family, style := parseFontFileName(tt.file)
+			assert.Equal(t, tt.family, family)
+			assert.Equal(t, tt.style, style)
+		})
+	}
+}
+
+func TestLoadFonts(t *testing.T) {
+	t.Run("Embedded fonts without a font directory", func(t *testing.T) {
+		families, err := loadFonts(filepath.Join(t.TempDir(), "missing"), []string{t.TempDir()}, nil)
+		require.NoError(t, err)
+		assert.Len(t, families, 1)
+		assert.Contains(t, families, defaultFontFamily)
+	})
+
+	t.Run("Font directory and declared fonts", func(t *testing.T) {
+		fontDir, themeDir := t.TempDir(), t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(fontDir, "Inter-Regular.ttf"), goregular.TTF, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(fontDir, "Inter-Bold.ttf"), gobold.TTF, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(fontDir, "README.txt"), []byte("not a font"), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(themeDir, "fonts"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(themeDir, "fonts", "brand.ttf"), gobold.TTF, 0644))
+
+		families, err := loadFonts(fontDir, []string{themeDir}, map[string]FontProp{
+			"Brand": {Bold: "fonts/brand.ttf"},
+		})
+		require.NoError(t, err)
+		assert.Len(t, families, 3)
+		assert.Len(t, families["Inter"], 2)
+		assert.Equal(t, gobold.TTF, families["Brand"]["B"])
+		// Styles that are not listed fall back to the closest listed style
+		assert.Equal(t, gobold.TTF, families["Brand"].style(""))
+	})
+
+	t.Run("Declared fonts of the themes a theme extends", func(t *testing.T) {
+		themeDir, parentDir := t.TempDir(), t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(parentDir, "brand.ttf"), gobold.TTF, 0644))
+
+		families, err := loadFonts("", []string{themeDir, parentDir}, map[string]FontProp{"Brand": {Regular: "brand.ttf"}})
+		require.NoError(t, err)
+		assert.Equal(t, gobold.TTF, families["Brand"][""])
+	})
+
+	t.Run("Declared font without files", func(t *testing.T) {
+		_, err := loadFonts("", []string{t.TempDir()}, map[string]FontProp{"Brand": {}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid font file", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "Broken-Regular.ttf"), []byte("not a font"), 0644))
+
+		_, err := loadFonts(dir, []string{t.TempDir()}, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestRegisterFonts(t *testing.T) {
+	t.Run("Embedded fonts", func(t *testing.T) {
+		families, err := loadFonts("", nil, nil)
+		require.NoError(t, err)
+
+		pdf := gofpdf.New("P", "mm", "A4", "")
+		family, err := registerFonts(pdf, families)
+		require.NoError(t, err)
+		assert.Equal(t, defaultFontFamily, family)
+
+		for _, style := range []string{"", "B", "I", "BI"} {
+			pdf.SetFont(family, style, 11)
+			assert.NoError(t, pdf.Error(), "style %q", style)
+		}
+	})
+
+	t.Run("Shipped Inter family", func(t *testing.T) {
+		families, err := loadFonts(filepath.Join("..", "..", "assets", "fonts"), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, families[preferredFontFamily], 4, "assets/fonts ships every style of Inter")
+
+		pdf := gofpdf.New("P", "mm", "A4", "")
+		family, err := registerFonts(pdf, families)
+		require.NoError(t, err)
+		assert.Equal(t, preferredFontFamily, family)
+
+		pdf.AddPage()
+		for _, style := range []string{"", "B", "I", "BI"} {
+			pdf.SetFont(family, style, 11)
+			pdf.Cell(40, 10, "Résumé")
+		}
+		var doc bytes.Buffer
+		require.NoError(t, pdf.Output(&doc))
+		for _, style := range []string{"", "B", "I", "BI"} {
+			assert.Contains(t, doc.String(), "/BaseFont /utf8inter"+style+"\n", "style %q", style)
+		}
+	})
+
+	t.Run("Preferred family", func(t *testing.T) {
+		families := map[string]fontFamily{
+			defaultFontFamily:   {"": goregular.TTF},
+			preferredFontFamily: {"": goregular.TTF, "B": gobold.TTF},
+		}
+
+		pdf := gofpdf.New("P", "mm", "A4", "")
+		family, err := registerFonts(pdf, families)
+		require.NoError(t, err)
+		assert.Equal(t, preferredFontFamily, family)
+
+		// Italic styles are not provided and fall back to the closest registered style
+		for _, style := range []string{"", "B", "I", "BI"} {
+			pdf.SetFont(family, style, 11)
+			assert.NoError(t, pdf.Error(), "style %q", style)
+		}
+	})
+}
+
+func TestPDFGenerator_PDFText(t *testing.T) {
+	pg := &PDFGenerator{}
+	var err error
+	pg.glyphs, err = newGlyphCoverage(map[string]fontFamily{defaultFontFamily: {"": goregular.TTF}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Zażółć gęślą jaźń → Ελληνικά Русский 日本語 ", pg.pdfText(defaultFontFamily, "Zażółć gęślą jaźń → Ελληνικά Русский 日本語 🚀"))
+	assert.Equal(t, map[rune]bool{'🚀': true}, pg.glyphs.dropped)
+	assert.Equal(t, map[rune]bool{'日': true, '本': true, '語': true}, pg.glyphs.missing[defaultFontFamily])
+}
+
+func TestPDFGenerator_FontRuns(t *testing.T) {
+	// Inter has no glyph for ∩, which the Go fonts have, and neither has CJK glyphs
+	families, err := loadFonts(filepath.Join("..", "..", "assets", "fonts"), nil, nil)
+	require.NoError(t, err)
+	pg := &PDFGenerator{fallbacks: []string{defaultFontFamily}}
+	pg.glyphs, err = newGlyphCoverage(families)
+	require.NoError(t, err)
+
+	assert.Equal(t, []textRun{
+		{family: preferredFontFamily, text: "A "},
+		{family: defaultFontFamily, text: "∩ "},
+		{family: preferredFontFamily, text: "B 日"},
+	}, pg.fontRuns(preferredFontFamily, "A ∩ B 日🚀"))
+	assert.Equal(t, map[string]map[rune]bool{preferredFontFamily: {'日': true}}, pg.glyphs.missing, "characters no font has are reported")
+	assert.Equal(t, map[rune]bool{'🚀': true}, pg.glyphs.dropped)
+
+	assert.True(t, pg.fallsBack(preferredFontFamily, "A ∩ B"))
+	assert.False(t, pg.fallsBack(preferredFontFamily, "A B 日"))
+
+	t.Run("Text column", func(t *testing.T) {
+		pdf := gofpdf.New("P", "mm", "A4", "")
+		pg.fontFamily, err = registerFonts(pdf, families)
+		require.NoError(t, err)
+		pdf.AddPage()
+
+		mixed := &colLayout{col: Col{Text: &TextProp{Content: "Sets ∩ maps"}}, width: 80}
+		pg.layoutText(pdf, mixed)
+		require.Len(t, mixed.rich, 1, "text with fallback characters is laid out as rich text")
+		var used []string
+		for _, f := range mixed.rich[0].fragments {
+			used = append(used, f.family+":"+f.text)
+		}
+		assert.Equal(t, []string{"Inter:Sets", "Inter: ", "Go:∩", "Inter: ", "Inter:maps"}, used)
+
+		plain := &colLayout{col: Col{Text: &TextProp{Content: "Sets and maps"}}, width: 80}
+		pg.layoutText(pdf, plain)
+		assert.Nil(t, plain.rich)
+		assert.Equal(t, []string{"Sets and maps"}, plain.lines)
+	})
+}
+
+func TestPDFGenerator_GenerateUnicode(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templateDir := filepath.Join(tempDir, "templates")
+	require.NoError(t, os.MkdirAll(filepath.Join(templateDir, "default"), 0755))
+
+	tmplContent := `
+rows:
+  - height: 10
+    cols:
+      - width: 12
+        text:
+          content: "{{.Basic.Name}}"
+          size: 14
+          style: bold
+  - height: 10
+    cols:
+      - width: 6
+        text:
+          content: "{{escapeYAML .Basic.Summary}}"
+          size: 10
+          style: italic
+      - width: 6
+        text:
+          content: "{{escapeYAML .Basic.Phrase}}"
+          size: 10
+          style: bolditalic
+`
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "default", "resume.yaml.tmpl"), []byte(tmplContent), 0644))
+
+	pg, err := NewPDFGenerator(outputDir, templateDir, "default", WithFontDir(filepath.Join(tempDir, "fonts")))
+	require.NoError(t, err)
+
+	data := &models.ResumeData{
+		Basic: models.BasicData{
+			Name:    "Łukasz Żółkiewski",
+			Summary: "Ingeniero de software → Ελληνικά, Русский, Čeština 🚀",
+			Phrase:  "日本語のテキスト",
+		},
+	}
+	require.NoError(t, pg.Generate(data, "pl"))
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "assets", "files", "resume-pl.pdf"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Contains(content, []byte("/ToUnicode")), "fonts should be embedded as Unicode fonts")
+	assert.False(t, bytes.Contains(content, []byte("/WinAnsiEncoding")), "text should not be encoded as cp1252")
+}
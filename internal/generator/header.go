@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/grafana/gofpdf"
+)
+
+const (
+	// runningGap is the space in millimeters between the content and the header or footer.
+	runningGap = 5.0
+	// defaultDateLayout formats the {date} placeholder when it has no layout.
+	defaultDateLayout = "2006-01-02"
+)
+
+// placeholder matches the placeholders of header and footer text: {page}, {pages}, {lang} and
+// {date}, optionally with a Go time layout as in {date:January 2006}.
+var placeholder = regexp.MustCompile(`\{(page|pages|lang|date)(?::([^{}]*))?\}`)
+
+// pageVars are the values of the header and footer placeholders that do not change between pages.
+type pageVars struct {
+	pages int       // Total number of pages, 0 until the document has been rendered once
+	date  time.Time // Generation date
+	lang  string
+}
+
+// expand replaces the placeholders of s with their values on the given page.
+func (v pageVars) expand(s string, page int) string {
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		sub := placeholder.FindStringSubmatch(m)
+		switch sub[1] {
+		case "page":
+			return strconv.Itoa(page)
+		case "pages":
+			return strconv.Itoa(v.pages)
+		case "lang":
+			return v.lang
+		}
+		layout := sub[2]
+		if layout == "" {
+			layout = defaultDateLayout
+		}
+		return v.date.Format(layout)
+	})
+}
+
+// usesPageCount reports whether the header or footer shows the total number of pages, which
+// is only known once the document has been rendered.
+func (t *Template) usesPageCount() bool {
+	found := false
+	find := func(s string) string {
+		for _, m := range placeholder.FindAllStringSubmatch(s, -1) {
+			found = found || m[1] == "pages"
+		}
+		return s
+	}
+	for _, r := range []*Row{t.Header, t.Footer} {
+		if r != nil {
+			expandCols(r.Cols, find)
+		}
+	}
+	return found
+}
+
+// expandCols returns a copy of cols with expand applied to the text of every column, list,
+// shape label and nested row. The template itself is left unchanged, so it can be expanded
+// again for the next page.
+func expandCols(cols []Col, expand func(string) string) []Col {
+	out := make([]Col, len(cols))
+	for i, col := range cols {
+		if col.Text != nil {
+			text := *col.Text
+			text.Content = expand(text.Content)
+			col.Text = &text
+		}
+		if col.List != nil {
+			list := *col.List
+			list.Content = expand(list.Content)
+			list.Items = make([]string, len(col.List.Items))
+			for j, item := range col.List.Items {
+				list.Items[j] = expand(item)
+			}
+			col.List = &list
+		}
+		if col.Shape != nil && col.Shape.Label != nil {
+			shape, label := *col.Shape, *col.Shape.Label
+			label.Content = expand(label.Content)
+			shape.Label = &label
+			col.Shape = &shape
+		}
+		if col.Rows != nil {
+			rows := make([]Row, len(col.Rows))
+			for j, r := range col.Rows {
+				r.Cols = expandCols(r.Cols, expand)
+				rows[j] = r
+			}
+			col.Rows = rows
+		}
+		out[i] = col
+	}
+	return out
+}
+
+// runningCols returns the columns of the header or footer with the placeholders of a page expanded.
+func (pg *PDFGenerator) runningCols(r *Row, page int) []Col {
+	return expandCols(r.Cols, func(s string) string { return pg.vars.expand(s, page) })
+}
+
+// reserveRunningSpace measures the header and footer and reserves their height and a gap on
+// every page, so rows break before the footer and start below the header.
+func (pg *PDFGenerator) reserveRunningSpace(pdf *gofpdf.Fpdf, t *Template) error {
+	pg.page.header, pg.page.footer = 0, 0
+	if t.Header != nil {
+		pg.page.header = rowHeight(t.Header.Height, pg.layoutCols(pdf, pg.page.content(), pg.runningCols(t.Header, 2))) + runningGap
+	}
+	if t.Footer != nil {
+		pg.page.footer = rowHeight(t.Footer.Height, pg.layoutCols(pdf, pg.page.content(), pg.runningCols(t.Footer, 1))) + runningGap
+	}
+	if pg.page.bottom() <= pg.page.top(2) {
+		return fmt.Errorf("header and footer leave no space for content on a %.1fx%.1f mm page", pg.page.width, pg.page.height)
+	}
+	return nil
+}
+
+// renderRunningRow renders the header or footer of the current page at y and restores the position.
+func (pg *PDFGenerator) renderRunningRow(pdf *gofpdf.Fpdf, r *Row, y float64) {
+	x, prevY := pdf.GetXY()
+	pdf.SetY(y)
+	pg.renderCols(pdf, pg.page.content(), r.Height, pg.runningCols(r, pdf.PageNo()))
+	pdf.SetXY(x, prevY)
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageVars_Expand(t *testing.T) {
+	vars := pageVars{pages: 3, date: time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), lang: "es"}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Page {page} of {pages}", want: "Page 2 of 3"},
+		{in: "{lang}", want: "es"},
+		{in: "{date}", want: "2025-03-07"},
+		{in: "{date:January 2006}", want: "March 2025"},
+		{in: "{name} {Page}", want: "{name} {Page}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, vars.expand(tt.in, 2))
+		})
+	}
+}
+
+func TestTemplate_UsesPageCount(t *testing.T) {
+	footer := func(content string) *Template {
+		return &Template{Footer: &Row{Cols: []Col{{Width: 12, Rows: []Row{{Cols: []Col{{Width: 12, Text: &TextProp{Content: content}}}}}}}}}
+	}
+
+	assert.True(t, footer("{page} / {pages}").usesPageCount())
+	assert.False(t, footer("Page {page}").usesPageCount())
+	assert.False(t, (&Template{Rows: []Row{{Cols: []Col{{Width: 12, Text: &TextProp{Content: "{pages}"}}}}}}).usesPageCount(),
+		"placeholders only apply to the header and footer")
+}
+
+func TestExpandCols(t *testing.T) {
+	cols := []Col{
+		{Width: 4, Text: &TextProp{Content: "{page}"}},
+		{Width: 4, List: &ListProp{Items: []string{"a {page}"}}},
+		{Width: 4, Shape: &ShapeProp{Label: &TextProp{Content: "{page}"}}},
+	}
+	expanded := expandCols(cols, func(s string) string { return pageVars{}.expand(s, 5) })
+
+	assert.Equal(t, "5", expanded[0].Text.Content)
+	assert.Equal(t, []string{"a 5"}, expanded[1].List.Items)
+	assert.Equal(t, "5", expanded[2].Shape.Label.Content)
+	assert.Equal(t, "{page}", cols[0].Text.Content, "the template keeps its placeholders")
+	assert.Equal(t, []string{"a {page}"}, cols[1].List.Items)
+}
+
+func TestPDFGenerator_RenderHeaderFooter(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	require.NoError(t, err)
+
+	text := func(content string) *TextProp { return &TextProp{Content: content, Size: 8} }
+	lineHeight := resolveTextStyle(nil, text("")).lineHeight()
+
+	tmpl := &Template{
+		Header: &Row{Height: 6, Cols: []Col{{Width: 12, Text: text("John Doe")}}},
+		Footer: &Row{Cols: []Col{{Width: 12, Text: text("Page {page} of {pages}")}}},
+	}
+	for i := 0; i < 60; i++ {
+		tmpl.Rows = append(tmpl.Rows, Row{Height: 10, Cols: []Col{{Width: 12, Text: text("x")}}})
+	}
+
+	pdf := newTestPDF(t, pg)
+	pdf.SetCompression(false)
+	pg.vars = pageVars{pages: 3}
+	require.NoError(t, pg.renderTemplate(pdf, tmpl))
+
+	assert.Equal(t, 6+runningGap, pg.page.top(2)-pg.page.top(1), "the header and its gap are reserved from the second page")
+	assert.InDelta(t, 297-10-lineHeight-runningGap, pg.page.bottom(), 0.01, "the footer and its gap are reserved on every page")
+	assert.Equal(t, 3, pdf.PageCount())
+
+	var out bytes.Buffer
+	require.NoError(t, pdf.Output(&out))
+	assert.Equal(t, 2, bytes.Count(out.Bytes(), pdfString("John Doe")), "the header repeats on pages 2+")
+	for _, footer := range []string{"Page 1 of 3", "Page 2 of 3", "Page 3 of 3"} {
+		assert.True(t, bytes.Contains(out.Bytes(), pdfString(footer)), footer)
+	}
+
+	t.Run("Header and footer taller than the page", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		err := pg.renderTemplate(pdf, &Template{Header: &Row{Height: 150}, Footer: &Row{Height: 150}})
+		assert.Error(t, err)
+	})
+}
+
+// pdfString encodes text as gofpdf writes it with UTF-8 fonts, in UTF-16BE.
+func pdfString(s string) []byte {
+	var b []byte
+	for _, r := range utf16.Encode([]rune(s)) {
+		b = binary.BigEndian.AppendUint16(b, r)
+	}
+	return b
+}
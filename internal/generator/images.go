@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/grafana/gofpdf"
+)
+
+// imageOrderSpan is the fraction of a pixel added to the width of images to order images of the
+// same width. It leaves the whole number of pixels written to the PDF unchanged.
+const imageOrderSpan = 1e-3
+
+// registerImage registers the image of a file, or of PNG data when data is not nil, under name,
+// and returns it, or nil if it cannot be read. Images registered through it are written to the
+// PDF in the same order on every run.
+func registerImage(pdf *gofpdf.Fpdf, name string, data []byte) *gofpdf.ImageInfoType {
+	if info := pdf.GetImageInfo(name); info != nil {
+		return info
+	}
+
+	var info *gofpdf.ImageInfoType
+	if data == nil {
+		info = pdf.RegisterImage(name, "")
+	} else {
+		info = pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(data))
+	}
+	if info == nil || pdf.Err() {
+		return nil
+	}
+	if err := orderImage(info); err != nil {
+		pdf.SetError(fmt.Errorf("image %s: %w", name, err))
+		return nil
+	}
+	return info
+}
+
+// orderImage gives an image a place in the PDF that depends only on its content. gofpdf writes
+// images in the random order of its image map, sorted by width alone when the catalog is
+// sorted, so images of the same width, such as icons, swap places between runs. The width gets
+// a fraction of a pixel taken from the checksum of the image, which orders images of the same
+// width by content and keeps identical images shared.
+func orderImage(info *gofpdf.ImageInfoType) error {
+	encoded, err := info.GobEncode()
+	if err != nil {
+		return err
+	}
+	// The fields of gofpdf.ImageInfoType, in the order of its GobEncode
+	var (
+		data, smask, pal []byte
+		n, bpc           int
+		w, h             float64
+		cs, filter, dp   string
+		trns             []int
+		scale, dpi       float64
+	)
+	fields := []any{&data, &smask, &n, &w, &h, &cs, &pal, &bpc, &filter, &dp, &trns, &scale, &dpi}
+	decoder := gob.NewDecoder(bytes.NewReader(encoded))
+	for _, field := range fields {
+		if err := decoder.Decode(field); err != nil {
+			return fmt.Errorf("decode image: %w", err)
+		}
+	}
+
+	checksum := sha1.Sum(encoded)
+	w += float64(binary.BigEndian.Uint32(checksum[:])) / (1 << 32) * imageOrderSpan
+
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+	for _, field := range fields {
+		if err := encoder.Encode(field); err != nil {
+			return fmt.Errorf("encode image: %w", err)
+		}
+	}
+	return info.GobDecode(buf.Bytes())
+}
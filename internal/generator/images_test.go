@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"bytes"
+	"image/color"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterImage(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	require.NoError(t, err)
+	dir := t.TempDir()
+	for name, c := range map[string]color.NRGBA{"red.png": {R: 255, A: 255}, "blue.png": {B: 255, A: 255}, "copy.png": {R: 255, A: 255}} {
+		writeTestPNG(t, filepath.Join(dir, name), 10, 5, c)
+	}
+
+	pdf := newTestPDF(t, pg)
+	red := registerImage(pdf, filepath.Join(dir, "red.png"), nil)
+	blue := registerImage(pdf, filepath.Join(dir, "blue.png"), nil)
+	copied := registerImage(pdf, filepath.Join(dir, "copy.png"), nil)
+	require.NotNil(t, red)
+	require.NotNil(t, blue)
+	assert.Same(t, red, registerImage(pdf, filepath.Join(dir, "red.png"), nil), "images are registered once")
+
+	assert.NotEqual(t, red.Width(), blue.Width(), "images of the same width are ordered by content")
+	assert.InDelta(t, red.Width(), blue.Width(), 0.001)
+	assert.Equal(t, red.Width(), copied.Width(), "identical images stay shared")
+
+	pdf.Image(filepath.Join(dir, "red.png"), 10, 10, 10, 0, false, "", 0, "")
+	pdf.Image(filepath.Join(dir, "blue.png"), 10, 20, 10, 0, false, "", 0, "")
+	var out bytes.Buffer
+	require.NoError(t, pdf.Output(&out))
+	assert.Equal(t, 2, strings.Count(out.String(), "/Width 10\n"), "the written width is unchanged")
+
+	assert.Nil(t, registerImage(newTestPDF(t, pg), filepath.Join(dir, "missing.png"), nil))
+}
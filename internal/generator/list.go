@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/gofpdf"
+)
+
+const (
+	// defaultBullet is the glyph of unordered list items.
+	defaultBullet = "•"
+	// defaultListIndent is the hanging indent of list items in millimeters.
+	defaultListIndent = 4.0
+	// defaultItemSpacing is the space between list items in millimeters.
+	defaultItemSpacing = 0.5
+)
+
+// listMarker matches the marker starting a list item: "- ", "* ", "+ ", "• ", "1. " or "1) ".
+var listMarker = regexp.MustCompile(`^(?:[-*+•]|\d+[.)])\s+`)
+
+// listItem is an item of a list. Text before the first item of a list split from a text is
+// a lead paragraph, rendered without a marker.
+type listItem struct {
+	text string
+	lead bool
+}
+
+// listItems returns the items of a list column.
+func (p *ListProp) listItems() []listItem {
+	if len(p.Items) > 0 {
+		items := make([]listItem, 0, len(p.Items))
+		for _, text := range p.Items {
+			items = append(items, listItem{text: strings.TrimSpace(text)})
+		}
+		return items
+	}
+	return splitListItems(p.Content)
+}
+
+// splitListItems splits a text into list items on its list markers. Lines without a marker
+// continue the previous item, and lines before the first marker form a lead paragraph.
+func splitListItems(text string) []listItem {
+	var items []listItem
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if marker := listMarker.FindString(line); marker != "" {
+			items = append(items, listItem{text: line[len(marker):]})
+			continue
+		}
+
+		if len(items) == 0 {
+			items = append(items, listItem{text: line, lead: true})
+		} else {
+			items[len(items)-1].text += " " + line
+		}
+	}
+	return items
+}
+
+// marker returns the bullet or number of the i-th item of a list.
+func (p *ListProp) marker(i int) string {
+	if p.Ordered {
+		start := p.Start
+		if start == 0 {
+			start = 1
+		}
+		return strconv.Itoa(start+i) + "."
+	}
+	if p.Bullet != "" {
+		return p.Bullet
+	}
+	return defaultBullet
+}
+
+// indent returns the hanging indent of the items.
+func (p *ListProp) indent() float64 {
+	if p.Indent > 0 {
+		return p.Indent
+	}
+	return defaultListIndent
+}
+
+// itemSpacing returns the space between items.
+func (p *ListProp) itemSpacing() float64 {
+	if p.ItemSpacing > 0 {
+		return p.ItemSpacing
+	}
+	return defaultItemSpacing
+}
+
+// layoutList measures the items of a list column. Every item is laid out as a text column
+// indented by the hanging indent, so its wrapped lines align with its first line.
+func (pg *PDFGenerator) layoutList(pdf *gofpdf.Fpdf, l *colLayout) {
+	p := l.col.List
+	indent := min(p.indent(), l.width/2)
+
+	for i, item := range p.listItems() {
+		text := &TextProp{Content: item.text, Size: p.Size, Style: p.Style, Color: p.Color, Markdown: p.Markdown}
+		il := &colLayout{col: Col{Text: text}, x: l.x + indent, width: l.width - indent}
+		if item.lead {
+			il.x, il.width = l.x, l.width
+		}
+		pg.layoutText(pdf, il)
+
+		if i > 0 {
+			l.height += p.itemSpacing()
+		}
+		l.height += il.height
+		l.items = append(l.items, il)
+		l.markers = append(l.markers, !item.lead)
+	}
+	l.style = resolveTextStyle(pg.styles, &TextProp{Size: p.Size, Style: p.Style, Color: p.Color})
+}
+
+// renderList renders the items of a list column at the current position. Page breaks fall
+// between items: an item that does not fit in the remaining space of the page moves to the
+// next page, unless it is taller than a page, in which case its lines continue there.
+func (pg *PDFGenerator) renderList(pdf *gofpdf.Fpdf, l *colLayout) {
+	p := l.col.List
+	pageBottom := pg.page.bottom()
+	number := 0
+
+	for i, item := range l.items {
+		if i > 0 {
+			pdf.SetY(pdf.GetY() + p.itemSpacing())
+		}
+
+		y, pageTop := pdf.GetY(), pg.page.top(pdf.PageNo())
+		fits := y+item.height <= pageBottom
+		fitsPage := item.height <= pageBottom-pageTop
+		firstLineFits := y+item.style.lineHeight() <= pageBottom
+		if y > pageTop && ((!fits && fitsPage) || !firstLineFits) {
+			pg.nextPage(pdf)
+		}
+
+		if l.markers[i] {
+			pg.renderMarker(pdf, l, item, p.marker(number))
+			number++
+		}
+		pg.renderTextFlow(pdf, item)
+	}
+}
+
+// renderListItems renders all items of a list column at the current Y position without page breaks.
+func (pg *PDFGenerator) renderListItems(pdf *gofpdf.Fpdf, l *colLayout) {
+	p := l.col.List
+	number := 0
+
+	for i, item := range l.items {
+		if i > 0 {
+			pdf.SetY(pdf.GetY() + p.itemSpacing())
+		}
+		if l.markers[i] {
+			pg.renderMarker(pdf, l, item, p.marker(number))
+			number++
+		}
+		pg.renderText(pdf, item, 0, item.lineCount())
+	}
+}
+
+// renderMarker renders the bullet or number of an item in the hanging indent, on the item's first line.
+func (pg *PDFGenerator) renderMarker(pdf *gofpdf.Fpdf, l, item *colLayout, marker string) {
+	y := pdf.GetY()
+	pg.setFont(pdf, TextStyle{Family: l.style.Family, Size: l.style.Size})
+	if l.style.Color != nil {
+		pdf.SetTextColor(l.style.Color.Red, l.style.Color.Green, l.style.Color.Blue)
+	} else {
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	pdf.SetXY(l.x, y)
+	pdf.CellFormat(item.x-l.x, item.style.lineHeight(), pg.pdfText(pg.family(l.style), marker), "", 0, "L", false, 0, "")
+	pdf.SetXY(item.x, y)
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitListItems(t *testing.T) {
+	text := `As a Go Developer, I have worked on
+a health data platform
+- Designed microservices
+  using Go
+* Mentored developers
+
+1. Numbered item
+2) Another one
+not a-list marker`
+
+	assert.Equal(t, []listItem{
+		{text: "As a Go Developer, I have worked on a health data platform", lead: true},
+		{text: "Designed microservices using Go"},
+		{text: "Mentored developers"},
+		{text: "Numbered item"},
+		{text: "Another one not a-list marker"},
+	}, splitListItems(text))
+}
+
+func TestListProp_Marker(t *testing.T) {
+	assert.Equal(t, defaultBullet, (&ListProp{}).marker(0))
+	assert.Equal(t, "–", (&ListProp{Bullet: "–"}).marker(3))
+	assert.Equal(t, "1.", (&ListProp{Ordered: true}).marker(0))
+	assert.Equal(t, "7.", (&ListProp{Ordered: true, Start: 5}).marker(2))
+}
+
+func TestPDFGenerator_RenderList(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	assert.NoError(t, err)
+
+	lineHeight := resolveTextStyle(nil, &TextProp{Size: 10}).lineHeight()
+	pdfMarginTop := 10.0
+	pageBottom := 297 - 10.0
+	page := region{x: 10, width: 190}
+
+	t.Run("Items are indented and spaced", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		list := &ListProp{Items: []string{"one", "two\nlines"}, Size: 10, Indent: 6, ItemSpacing: 2}
+		cols := pg.layoutCols(pdf, page, []Col{{Width: 12, List: list}})
+		assert.Len(t, cols[0].items, 2)
+		assert.InDelta(t, 16, cols[0].items[0].x, 0.01)
+		assert.InDelta(t, 3*lineHeight+2, cols[0].height, 0.01)
+
+		pg.renderRow(pdf, page, &Row{Cols: []Col{{Width: 12, List: list}}})
+		assert.InDelta(t, pdfMarginTop+3*lineHeight+2, pdf.GetY(), 0.01)
+	})
+
+	t.Run("Lead paragraph is not indented", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		cols := pg.layoutCols(pdf, page, []Col{{Width: 12, List: &ListProp{Content: "Intro\n- item"}}})
+		assert.Equal(t, []bool{false, true}, cols[0].markers)
+		assert.InDelta(t, page.x, cols[0].items[0].x, 0.01)
+	})
+
+	t.Run("Item that does not fit moves to the next page", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetY(pageBottom - 2.5*lineHeight)
+		list := &ListProp{Items: []string{"one", "two\nlines\nhere"}, Size: 10, ItemSpacing: 1}
+		pg.renderRow(pdf, page, &Row{Cols: []Col{{Width: 12, List: list}}})
+		assert.Equal(t, 2, pdf.PageCount())
+		// The first item stays on the first page, the three lines of the second move together
+		assert.InDelta(t, pdfMarginTop+3*lineHeight, pdf.GetY(), 0.01)
+	})
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/gofpdf"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+// setMetadata fills the document information dictionary: the name and professional title as
+// the title, the name as the author, the professional title as the subject, and the title and
+// skills as keywords.
+func setMetadata(pdf *gofpdf.Fpdf, data *models.ResumeData) {
+	name, title := data.Basic.Name, data.Professional.Title
+
+	docTitle := name
+	if title != "" {
+		docTitle = name + " - " + title
+	}
+	pdf.SetTitle(docTitle, true)
+	pdf.SetAuthor(name, true)
+	pdf.SetSubject(title, true)
+
+	var keywords []string
+	if title != "" {
+		keywords = append(keywords, title)
+	}
+	for _, s := range data.Skills {
+		keywords = append(keywords, s.Name)
+	}
+	pdf.SetKeywords(strings.Join(keywords, ", "), true)
+}
+
+// setDocumentLang adds the language of the document to the catalog of a PDF written by gofpdf,
+// which has no setting for it. The catalog is the last object before the cross-reference
+// table, so only the offset of the table moves.
+func setDocumentLang(doc []byte, lang string) ([]byte, error) {
+	catalog := []byte("/Type /Catalog\n")
+	i := bytes.LastIndex(doc, catalog)
+	if i < 0 {
+		return nil, fmt.Errorf("set language: document catalog not found")
+	}
+	entry := []byte("/Lang (" + escapePDFString(lang) + ")\n")
+
+	startxref := []byte("startxref\n")
+	j := bytes.LastIndex(doc, startxref)
+	if j < i {
+		return nil, fmt.Errorf("set language: cross-reference offset not found")
+	}
+	offsetStart := j + len(startxref)
+	offsetEnd := offsetStart + bytes.IndexByte(doc[offsetStart:], '\n')
+	if offsetEnd < offsetStart {
+		return nil, fmt.Errorf("set language: cross-reference offset not found")
+	}
+	offset, err := strconv.Atoi(string(doc[offsetStart:offsetEnd]))
+	if err != nil {
+		return nil, fmt.Errorf("set language: %w", err)
+	}
+
+	out := make([]byte, 0, len(doc)+len(entry)+2)
+	out = append(out, doc[:i+len(catalog)]...)
+	out = append(out, entry...)
+	out = append(out, doc[i+len(catalog):offsetStart]...)
+	out = strconv.AppendInt(out, int64(offset+len(entry)), 10)
+	out = append(out, doc[offsetEnd:]...)
+	return out, nil
+}
+
+// escapePDFString escapes the delimiters of a PDF literal string.
+func escapePDFString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s)
+}
+
+// addBookmark adds the outline entry of a row at the current position.
+func addBookmark(pdf *gofpdf.Fpdf, r *Row) {
+	if r.Bookmark != "" {
+		pdf.Bookmark(r.Bookmark, r.BookmarkLevel, -1)
+	}
+}
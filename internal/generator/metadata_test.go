@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestSetDocumentLang(t *testing.T) {
+	pdf := newTestPDF(t, &PDFGenerator{})
+	var doc bytes.Buffer
+	require.NoError(t, pdf.Output(&doc))
+
+	out, err := setDocumentLang(doc.Bytes(), "es")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "/Type /Catalog\n/Lang (es)\n")
+	assertValidXref(t, out)
+
+	_, err = setDocumentLang([]byte("not a PDF"), "es")
+	assert.Error(t, err)
+}
+
+func TestPDFGenerator_GenerateMetadata(t *testing.T) {
+	tempDir := t.TempDir()
+	templateDir := filepath.Join(tempDir, "templates")
+	require.NoError(t, os.MkdirAll(filepath.Join(templateDir, "default"), 0755))
+	tmplContent := `
+rows:
+  - bookmark: Experience
+    cols:
+      - width: 12
+        text:
+          content: "Experience"
+  - bookmark: Globant
+    bookmark_level: 1
+    rows:
+      - cols:
+          - width: 12
+            text:
+              content: "Globant"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "default", "resume.yaml.tmpl"), []byte(tmplContent), 0644))
+
+	pg, err := NewPDFGenerator(filepath.Join(tempDir, "output"), templateDir, "default")
+	require.NoError(t, err)
+	data := &models.ResumeData{
+		Basic:        models.BasicData{Name: "John Doe"},
+		Professional: models.ProfessionalData{Title: "Software Architect"},
+		Skills:       []models.Skill{{Name: "Go"}, {Name: "Java"}},
+	}
+	require.NoError(t, pg.Generate(data, "es"))
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "output", "assets", "files", "resume-es.pdf"))
+	require.NoError(t, err)
+	assertValidXref(t, content)
+
+	info := func(key, value string) []byte {
+		return append([]byte("/"+key+" (\xfe\xff"), pdfString(value)...)
+	}
+	assert.True(t, bytes.Contains(content, info("Title", "John Doe - Software Architect")))
+	assert.True(t, bytes.Contains(content, info("Author", "John Doe")))
+	assert.True(t, bytes.Contains(content, info("Subject", "Software Architect")))
+	assert.True(t, bytes.Contains(content, info("Keywords", "Software Architect, Go, Java")))
+	assert.Contains(t, string(content), "/Lang (es)")
+	assert.Contains(t, string(content), "/PageMode /UseOutlines")
+	assert.True(t, bytes.Contains(content, info("Title", "Experience")))
+	assert.True(t, bytes.Contains(content, info("Title", "Globant")))
+}
+
+// assertValidXref checks that the cross-reference table of a PDF points at its objects.
+func assertValidXref(t *testing.T, doc []byte) {
+	t.Helper()
+	i := bytes.LastIndex(doc, []byte("startxref\n"))
+	require.GreaterOrEqual(t, i, 0)
+	offset, err := strconv.Atoi(string(bytes.Fields(doc[i+len("startxref\n"):])[0]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(doc[offset:], []byte("xref\n")), "startxref points at the cross-reference table")
+
+	lines := bytes.Split(doc[offset:], []byte("\n"))
+	count, err := strconv.Atoi(string(bytes.Fields(lines[1])[1]))
+	require.NoError(t, err)
+	for n := 1; n < count; n++ {
+		objOffset, err := strconv.Atoi(string(bytes.Fields(lines[2+n])[0]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(doc[objOffset:], []byte(strconv.Itoa(n)+" 0 obj")), "object %d", n)
+	}
+}
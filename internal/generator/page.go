@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pageSizes lists the named page sizes in portrait millimeters.
+var pageSizes = map[string][2]float64{
+	"a3":     {297, 420},
+	"a4":     {210, 297},
+	"a5":     {148, 210},
+	"letter": {215.9, 279.4},
+	"legal":  {215.9, 355.6},
+}
+
+// defaultPage returns the page settings of templates that do not declare them: A4 portrait
+// with 10 mm margins.
+func defaultPage() Page {
+	return Page{
+		Size:        "A4",
+		Orientation: "portrait",
+		Margins:     Margins{Top: 10, Right: 10, Bottom: 10, Left: 10},
+	}
+}
+
+// pageLayout is the resolved geometry of the pages of a document in millimeters.
+type pageLayout struct {
+	orientation string // gofpdf orientation, P or L
+	size        [2]float64
+	width       float64
+	height      float64
+	margins     Margins
+	header      float64 // Space reserved for the header on pages 2+, including the gap below it
+	footer      float64 // Space reserved for the footer on every page, including the gap above it
+}
+
+// layout resolves the page settings into the page geometry. A non-empty size overrides the
+// size of the template.
+func (p Page) layout(size string) (pageLayout, error) {
+	if size == "" {
+		size = p.Size
+	}
+	dims, err := parsePageSize(size)
+	if err != nil {
+		return pageLayout{}, err
+	}
+
+	l := pageLayout{orientation: "P", size: dims, width: dims[0], height: dims[1], margins: p.Margins}
+	switch strings.ToLower(p.Orientation) {
+	case "", "portrait":
+	case "landscape":
+		l.orientation = "L"
+		l.width, l.height = dims[1], dims[0]
+	default:
+		return pageLayout{}, fmt.Errorf("page orientation must be portrait or landscape, got %q", p.Orientation)
+	}
+
+	m := p.Margins
+	if m.Top < 0 || m.Right < 0 || m.Bottom < 0 || m.Left < 0 {
+		return pageLayout{}, fmt.Errorf("page margins cannot be negative")
+	}
+	if m.Left+m.Right >= l.width || m.Top+m.Bottom >= l.height {
+		return pageLayout{}, fmt.Errorf("page margins leave no space for content on a %.1fx%.1f mm page", l.width, l.height)
+	}
+
+	return l, nil
+}
+
+// parsePageSize returns the portrait dimensions of a named page size (A3, A4, A5, Letter, Legal)
+// or of a custom size written as "<width>x<height>" in millimeters.
+func parsePageSize(size string) ([2]float64, error) {
+	if dims, ok := pageSizes[strings.ToLower(size)]; ok {
+		return dims, nil
+	}
+
+	w, h, ok := strings.Cut(strings.ToLower(size), "x")
+	if ok {
+		width, errW := strconv.ParseFloat(strings.TrimSpace(w), 64)
+		height, errH := strconv.ParseFloat(strings.TrimSpace(h), 64)
+		if errW == nil && errH == nil && width > 0 && height > 0 {
+			return [2]float64{width, height}, nil
+		}
+	}
+
+	return [2]float64{}, fmt.Errorf("unknown page size %q: use A3, A4, A5, Letter, Legal or <width>x<height> in mm", size)
+}
+
+// content returns the region between the left and right margins.
+func (l pageLayout) content() region {
+	return region{x: l.margins.Left, width: l.width - l.margins.Left - l.margins.Right}
+}
+
+// top returns the Y position where content starts on a page, below the header from the second page.
+func (l pageLayout) top(page int) float64 {
+	if page > 1 {
+		return l.margins.Top + l.header
+	}
+	return l.margins.Top
+}
+
+// bottom returns the Y position where content ends on a page, above the footer.
+func (l pageLayout) bottom() float64 {
+	return l.height - l.margins.Bottom - l.footer
+}
+
+// footerY returns the Y position of the footer, which ends at the bottom margin.
+func (l pageLayout) footerY() float64 {
+	return l.bottom() + runningGap
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePageSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    [2]float64
+		wantErr bool
+	}{
+		{size: "A4", want: [2]float64{210, 297}},
+		{size: "letter", want: [2]float64{215.9, 279.4}},
+		{size: "Legal", want: [2]float64{215.9, 355.6}},
+		{size: "200x280", want: [2]float64{200, 280}},
+		{size: "100.5 X 150", want: [2]float64{100.5, 150}},
+		{size: "B5", wantErr: true},
+		{size: "0x280", wantErr: true},
+		{size: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, err := parsePageSize(tt.size)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPage_Layout(t *testing.T) {
+	t.Run("Default page", func(t *testing.T) {
+		l, err := defaultPage().layout("")
+		require.NoError(t, err)
+		assert.Equal(t, "P", l.orientation)
+		assert.Equal(t, region{x: 10, width: 190}, l.content())
+		assert.Equal(t, 10.0, l.top(2))
+		assert.Equal(t, 287.0, l.bottom())
+		assert.Equal(t, 292.0, l.footerY())
+	})
+
+	t.Run("Landscape with size override", func(t *testing.T) {
+		p := Page{Size: "A4", Orientation: "landscape", Margins: Margins{Top: 5, Right: 8, Bottom: 12, Left: 8}}
+		l, err := p.layout("Letter")
+		require.NoError(t, err)
+		assert.Equal(t, "L", l.orientation)
+		assert.Equal(t, [2]float64{215.9, 279.4}, l.size)
+		assert.Equal(t, 279.4, l.width)
+		assert.Equal(t, 215.9, l.height)
+		assert.InDelta(t, 263.4, l.content().width, 0.001)
+		assert.InDelta(t, 203.9, l.bottom(), 0.001)
+	})
+
+	t.Run("Invalid settings", func(t *testing.T) {
+		_, err := Page{Size: "A4", Orientation: "sideways"}.layout("")
+		assert.Error(t, err)
+		_, err = Page{Size: "A4", Margins: Margins{Left: -1}}.layout("")
+		assert.Error(t, err)
+		_, err = Page{Size: "A4", Margins: Margins{Left: 110, Right: 100}}.layout("")
+		assert.Error(t, err)
+		_, err = defaultPage().layout("huge")
+		assert.Error(t, err)
+	})
+}
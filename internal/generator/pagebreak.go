@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/gofpdf"
+)
+
+// checkRows verifies the page break rules of the rows, that groups have no columns and that
+// every bookmark is at most one level below the previous one. The header and footer are drawn
+// on every page, so they cannot group rows, break pages or add bookmarks.
+func (t *Template) checkRows() error {
+	var err error
+	level := -1
+	t.walkRows(func(path string, r *Row) bool {
+		switch strings.ToLower(r.PageBreak) {
+		case "", "before", "after":
+		default:
+			err = fmt.Errorf("%s: page_break must be before or after, got %q", path, r.PageBreak)
+		}
+		if r.Rows != nil && r.Cols != nil {
+			err = fmt.Errorf("%s: a row has either cols or rows, not both", path)
+		}
+		if r.Bookmark != "" {
+			if r.BookmarkLevel < 0 || r.BookmarkLevel > level+1 {
+				err = fmt.Errorf("%s: bookmark %q has level %d, but the previous bookmark has level %d", path, r.Bookmark, r.BookmarkLevel, level)
+			}
+			level = r.BookmarkLevel
+		}
+		running := strings.HasPrefix(path, "header") || strings.HasPrefix(path, "footer")
+		if running && (r.Rows != nil || r.KeepTogether || r.KeepWithNext || r.PageBreak != "" || r.Bookmark != "") {
+			err = fmt.Errorf("%s: header and footer rows cannot group rows, break pages or add bookmarks", path)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// atPageTop reports whether the current position is where content starts on the current page.
+func (pg *PDFGenerator) atPageTop(pdf *gofpdf.Fpdf) bool {
+	return pdf.GetY() <= pg.page.top(pdf.PageNo())+0.001
+}
+
+// keepRows moves to the next page when rows[0] must stay with more than fits in the rest of the
+// page. Rules that cannot be met on an empty page either are ignored, so the rows split as usual.
+func (pg *PDFGenerator) keepRows(pdf *gofpdf.Fpdf, rg region, rows []Row) {
+	if (!rows[0].KeepTogether && !rows[0].KeepWithNext) || pg.atPageTop(pdf) {
+		return
+	}
+	need := pg.keepHeight(pdf, rg, rows)
+	if pdf.GetY()+need > pg.page.bottom() && need <= pg.page.bottom()-pg.page.top(pdf.PageNo()+1) {
+		pg.nextPage(pdf)
+	}
+}
+
+// keepHeight returns the height that has to fit on the page for rows[0] to start there: the
+// whole row when it is kept together or with the next row, plus what the next row needs, and
+// otherwise only its first line.
+func (pg *PDFGenerator) keepHeight(pdf *gofpdf.Fpdf, rg region, rows []Row) float64 {
+	r := &rows[0]
+	height, head := pg.measureRow(pdf, rg, r)
+	switch {
+	case r.KeepWithNext && len(rows) > 1:
+		return height + pg.keepHeight(pdf, rg, rows[1:])
+	case r.KeepTogether || r.KeepWithNext:
+		return height
+	}
+	return head
+}
+
+// measureRow returns the height of a row or group, and the height of its start that cannot be
+// split: the first line of text, or the whole row when it moves to the next page whole.
+func (pg *PDFGenerator) measureRow(pdf *gofpdf.Fpdf, rg region, r *Row) (height, head float64) {
+	if r.Rows != nil {
+		for i := range r.Rows {
+			h, _ := pg.measureRow(pdf, rg, &r.Rows[i])
+			height += h
+		}
+		if len(r.Rows) > 0 {
+			head = pg.keepHeight(pdf, rg, r.Rows)
+		}
+		return max(height, r.Height), head
+	}
+
+	cols := pg.layoutCols(pdf, rg, r.Cols)
+	height = rowHeight(r.Height, cols)
+	if !splittable(cols) {
+		return height, height
+	}
+	for _, l := range cols {
+		switch {
+		case l.col.Text != nil:
+			head = max(head, l.style.lineHeight())
+		case l.col.List != nil && len(l.items) > 0:
+			head = max(head, l.items[0].style.lineHeight())
+		case len(l.col.Rows) > 0:
+			head = max(head, pg.keepHeight(pdf, region{x: l.x, width: l.width}, l.col.Rows))
+		}
+	}
+	return height, min(head, height)
+}
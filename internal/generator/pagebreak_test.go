@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_CheckRows(t *testing.T) {
+	text := []Col{{Width: 12, Text: &TextProp{Content: "x"}}}
+
+	tests := []struct {
+		name    string
+		tmpl    Template
+		wantErr bool
+	}{
+		{name: "Group with page rules", tmpl: Template{Rows: []Row{{KeepTogether: true, PageBreak: "before", Rows: []Row{{KeepWithNext: true, Cols: text}, {Cols: text}}}}}},
+		{name: "Page break in a nested grid", tmpl: Template{Rows: []Row{{Cols: []Col{{Width: 12, Rows: []Row{{PageBreak: "After", Cols: text}}}}}}}},
+		{name: "Unknown page break", tmpl: Template{Rows: []Row{{PageBreak: "middle", Cols: text}}}, wantErr: true},
+		{name: "Group with columns", tmpl: Template{Rows: []Row{{Cols: text, Rows: []Row{{Cols: text}}}}}, wantErr: true},
+		{name: "Footer with a page rule", tmpl: Template{Footer: &Row{KeepTogether: true, Cols: text}}, wantErr: true},
+		{name: "Header group", tmpl: Template{Header: &Row{Rows: []Row{{Cols: text}}}}, wantErr: true},
+		{name: "Bookmark tree", tmpl: Template{Rows: []Row{
+			{Bookmark: "Experience", Cols: text},
+			{Bookmark: "Globant", BookmarkLevel: 1, Rows: []Row{{Bookmark: "Projects", BookmarkLevel: 2, Cols: text}}},
+			{Bookmark: "Education", Cols: text},
+		}}},
+		{name: "Bookmark skipping a level", tmpl: Template{Rows: []Row{{Bookmark: "Globant", BookmarkLevel: 1, Cols: text}}}, wantErr: true},
+		{name: "Footer bookmark", tmpl: Template{Footer: &Row{Bookmark: "Footer", Cols: text}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tmpl.checkRows()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPDFGenerator_MeasureRow(t *testing.T) {
+	pg := &PDFGenerator{}
+	pdf := newTestPDF(t, pg)
+	page := pg.page.content()
+	text := &TextProp{Content: "one\ntwo\nthree", Size: 10}
+	lineHeight := resolveTextStyle(nil, text).lineHeight()
+
+	height, head := pg.measureRow(pdf, page, &Row{Cols: []Col{{Width: 12, Text: text}}})
+	assert.InDelta(t, 3*lineHeight, height, 0.01)
+	assert.InDelta(t, lineHeight, head, 0.01, "text splits after its first line")
+
+	height, head = pg.measureRow(pdf, page, &Row{Cols: []Col{{Width: 12, Background: &Color{}, Text: text}}})
+	assert.InDelta(t, height, head, 0.01, "rows that move whole cannot split")
+
+	height, head = pg.measureRow(pdf, page, &Row{Rows: []Row{
+		{Height: 8, KeepWithNext: true, Cols: []Col{{Width: 12, Text: &TextProp{Content: "Experience"}}}},
+		{Cols: []Col{{Width: 12, Text: text}}},
+	}})
+	assert.InDelta(t, 8+3*lineHeight, height, 0.01)
+	assert.InDelta(t, 8+lineHeight, head, 0.01, "a group starts with what its first rows keep together")
+}
+
+func TestPDFGenerator_RenderPageBreaks(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	require.NoError(t, err)
+	newTestPDF(t, pg) // Lays out the page
+
+	text := &TextProp{Content: "one\ntwo\nthree", Size: 10}
+	lineHeight := resolveTextStyle(nil, text).lineHeight()
+	heading := Row{Height: 8, Cols: []Col{{Width: 12, Text: &TextProp{Content: "Experience"}}}}
+	entry := Row{Cols: []Col{{Width: 12, Text: text}}}
+
+	render := func(y float64, rows ...Row) (page int, endY float64) {
+		pdf := newTestPDF(t, pg)
+		if y > 0 {
+			pdf.SetY(y)
+		}
+		pg.renderRows(pdf, pg.page.content(), rows)
+		require.NoError(t, pdf.Error())
+		return pdf.PageNo(), pdf.GetY()
+	}
+
+	t.Run("Heading without rules stays alone at the bottom", func(t *testing.T) {
+		page, y := render(pg.page.bottom()-9, heading, entry)
+		assert.Equal(t, 2, page)
+		assert.InDelta(t, pg.page.top(2)+3*lineHeight, y, 0.01)
+	})
+
+	t.Run("Heading kept with the next row", func(t *testing.T) {
+		kept := heading
+		kept.KeepWithNext = true
+		page, y := render(pg.page.bottom()-9, kept, entry)
+		assert.Equal(t, 2, page)
+		assert.InDelta(t, pg.page.top(2)+8+3*lineHeight, y, 0.01)
+
+		// The heading and the first line of the entry fit, so the entry splits as usual
+		page, y = render(pg.page.bottom()-8-lineHeight-0.01, kept, entry)
+		assert.Equal(t, 2, page)
+		assert.InDelta(t, pg.page.top(2)+2*lineHeight, y, 0.01)
+	})
+
+	t.Run("Group kept together", func(t *testing.T) {
+		group := Row{KeepTogether: true, Rows: []Row{heading, entry}}
+		page, y := render(pg.page.bottom()-8-2*lineHeight, group)
+		assert.Equal(t, 2, page)
+		assert.InDelta(t, pg.page.top(2)+8+3*lineHeight, y, 0.01)
+	})
+
+	t.Run("Rule that cannot be met on an empty page is ignored", func(t *testing.T) {
+		tall := Row{KeepTogether: true, Height: 400, Cols: []Col{{Width: 12, Text: text}}}
+		page, _ := render(pg.page.bottom()-50, tall)
+		assert.Equal(t, 1, page)
+	})
+
+	t.Run("Row without content ends at the page bottom", func(t *testing.T) {
+		spacer := Row{Height: 10, Cols: []Col{{Width: 12, Text: &TextProp{Content: ""}}}}
+		page, y := render(pg.page.bottom()-5, spacer, Row{Height: 8, Cols: []Col{}})
+		assert.Equal(t, 1, page, "spacers do not start a page")
+		assert.InDelta(t, pg.page.bottom(), y, 0.01)
+
+		page, _ = render(pg.page.bottom()-5, spacer, entry)
+		assert.Equal(t, 2, page, "content after the spacer starts the next page")
+	})
+
+	t.Run("Page break before and after", func(t *testing.T) {
+		before := entry
+		before.PageBreak = "before"
+		page, y := render(0, entry, before)
+		assert.Equal(t, 2, page)
+		assert.InDelta(t, pg.page.top(2)+3*lineHeight, y, 0.01)
+
+		page, _ = render(0, before)
+		assert.Equal(t, 1, page, "no empty page before the first row")
+
+		after := entry
+		after.PageBreak = "after"
+		page, _ = render(0, after, entry)
+		assert.Equal(t, 2, page)
+
+		page, _ = render(0, entry, after)
+		assert.Equal(t, 1, page, "no empty page after the last row")
+	})
+}
@@ -2,6 +2,7 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,16 +18,8 @@ import (
 )
 
 const (
-	// PDF page margins in millimeters
-	pdfMarginLeft   = 10.0
-	pdfMarginTop    = 10.0
-	pdfMarginRight  = 10.0
-	pdfMarginBottom = 20.0 // Space for footer
-
-	// A4 page dimensions and grid
-	a4WidthMM       = 210.0
-	a4UsableWidthMM = a4WidthMM - pdfMarginLeft - pdfMarginRight
-	marotoCols      = 12.0 // Keep the 12-column grid concept
+	// Grid of every row
+	marotoCols = 12.0 // Keep the 12-column grid concept
 )
 
 // PDFGenerator handles PDF resume generation using the gofpdf library.
@@ -34,22 +27,77 @@ type PDFGenerator struct {
 	outputDir   string
 	templateDir string
 	theme       string
-	tr          func(string) string
+	fontDir     string
+	pageSize    string
+	fontFamily  string
+	fallbacks   []string       // Font families tried for characters the font of a text lacks
+	glyphs      *glyphCoverage // Characters the font families have glyphs for
+	styles      map[string]TextStyle
+	page        pageLayout
+	svgs        map[string]*svgImage // Parsed SVG images by path
+	vars        pageVars             // Values of the header and footer placeholders
+	timestamp   time.Time            // Pinned generation time, or zero for the current time
+	maxPages    int                  // Pages the template shrinks to fit in, or 0 for any number
+	sections    []section            // Sections of the last rendered document
+	themeValues map[string]any       // Configured values of the theme options
 }
 
-// NewPDFGenerator creates a new PDF generator with the specified configuration.
-func NewPDFGenerator(outputDir, templateDir, theme string) (*PDFGenerator, error) {
-	// Create a temporary PDF instance to get the translator
-	// This is a bit of a workaround, but the translator is attached to the Fpdf struct in this library version
-	tempPdf := gofpdf.New("P", "mm", "A4", "")
-	tr := tempPdf.UnicodeTranslatorFromDescriptor("") // Default to cp1252
+// PDFOption configures optional behaviour of the PDFGenerator.
+type PDFOption func(*PDFGenerator)
+
+// WithFontDir registers the TTF files in dir with the PDF in addition to the embedded fonts.
+func WithFontDir(dir string) PDFOption {
+	return func(pg *PDFGenerator) {
+		pg.fontDir = dir
+	}
+}
+
+// WithPageSize overrides the page size of the template with a named size (A4, Letter, Legal)
+// or a custom "<width>x<height>" size in millimeters. An empty size keeps the template's size.
+func WithPageSize(size string) PDFOption {
+	return func(pg *PDFGenerator) {
+		pg.pageSize = size
+	}
+}
 
-	return &PDFGenerator{
+// WithTimestamp pins the creation and modification dates of the PDF and the generation date
+// that templates show to t, so unchanged data renders to a byte-identical PDF. A zero time
+// keeps the current time.
+func WithTimestamp(t time.Time) PDFOption {
+	return func(pg *PDFGenerator) {
+		pg.timestamp = t
+	}
+}
+
+// WithMaxPages shrinks the font sizes, line spacing and vertical spacing of the template, within
+// the fit bounds of the template, until the PDF fits in n pages. Zero renders the template at
+// full size on as many pages as it needs.
+func WithMaxPages(n int) PDFOption {
+	return func(pg *PDFGenerator) {
+		pg.maxPages = n
+	}
+}
+
+// WithPDFThemeOptions sets options declared in the theme.yaml of the theme, or of the themes it
+// extends. Templates read the options as .Theme, and components rendered with other data with the
+// theme function. Options left unset keep their defaults.
+func WithPDFThemeOptions(values map[string]any) PDFOption {
+	return func(pg *PDFGenerator) {
+		pg.themeValues = values
+	}
+}
+
+// NewPDFGenerator creates a new PDF generator with the specified configuration.
+func NewPDFGenerator(outputDir, templateDir, theme string, opts ...PDFOption) (*PDFGenerator, error) {
+	pg := &PDFGenerator{
 		outputDir:   outputDir,
 		templateDir: templateDir,
 		theme:       theme,
-		tr:          tr,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(pg)
+	}
+	return pg, nil
 }
 
 // Generate creates a PDF resume from the provided resume data and language.
@@ -67,16 +115,57 @@ func (pg *PDFGenerator) Generate(data *models.ResumeData, lang string) error {
 	}
 
 	// Configure PDF document
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetMargins(pdfMarginLeft, pdfMarginTop, pdfMarginRight)
-	pdf.SetAutoPageBreak(true, pdfMarginBottom)
-	pdf.AddPage()
+	page, err := tmpl.Page.layout(pg.pageSize)
+	if err != nil {
+		return fmt.Errorf("page settings: %w", err)
+	}
+	pg.page = page
 
-	// Set default font
-	pdf.SetFont("Arial", "", 11)
+	// Load UTF-8 fonts so every language renders with its own characters
+	dirs, err := themeDirs(pg.templateDir, pg.theme)
+	if err != nil {
+		return err
+	}
+	families, err := loadFonts(pg.fontDir, dirs, tmpl.Fonts)
+	if err != nil {
+		return fmt.Errorf("load fonts: %w", err)
+	}
+	for name, style := range tmpl.Styles {
+		if _, ok := families[style.Family]; style.Family != "" && !ok {
+			return fmt.Errorf("style %s: unknown font family %s", name, style.Family)
+		}
+	}
+	for _, family := range tmpl.FallbackFonts {
+		if _, ok := families[family]; !ok {
+			return fmt.Errorf("fallback fonts: unknown font family %s", family)
+		}
+	}
+	pg.fallbacks = tmpl.FallbackFonts
+	if pg.glyphs, err = newGlyphCoverage(families); err != nil {
+		return fmt.Errorf("load fonts: %w", err)
+	}
+	pg.styles = tmpl.Styles
+	pg.vars = pageVars{date: pg.now(), lang: lang}
 
-	// Render template into PDF
-	pg.renderTemplate(pdf, tmpl)
+	pdf, err := pg.render(tmpl, families)
+	if err != nil {
+		return err
+	}
+	if pg.maxPages > 0 && pdf.PageCount() > pg.maxPages {
+		if tmpl, pdf, err = pg.fit(data, families); err != nil {
+			return err
+		}
+	}
+	// The total number of pages is known once the document has been rendered
+	if tmpl.usesPageCount() {
+		pg.vars.pages = pdf.PageCount()
+		if pdf, err = pg.render(tmpl, families); err != nil {
+			return err
+		}
+	}
+
+	pg.glyphs.warn()
+	setMetadata(pdf, data)
 
 	// Generate PDF document
 	filename := "resume.pdf"
@@ -89,7 +178,15 @@ func (pg *PDFGenerator) Generate(data *models.ResumeData, lang string) error {
 		return fmt.Errorf("create output directory: %w", err)
 	}
 
-	if err := pdf.OutputFileAndClose(pdfPath); err != nil {
+	var doc bytes.Buffer
+	if err := pdf.Output(&doc); err != nil {
+		return fmt.Errorf("save PDF: %w", err)
+	}
+	content, err := setDocumentLang(doc.Bytes(), lang)
+	if err != nil {
+		return fmt.Errorf("save PDF: %w", err)
+	}
+	if err := os.WriteFile(pdfPath, content, 0644); err != nil {
 		return fmt.Errorf("save PDF: %w", err)
 	}
 
@@ -97,99 +194,414 @@ func (pg *PDFGenerator) Generate(data *models.ResumeData, lang string) error {
 	return nil
 }
 
+// render creates a PDF document with the page settings and fonts of the template and renders
+// the template into it.
+func (pg *PDFGenerator) render(t *Template, families map[string]fontFamily) (*gofpdf.Fpdf, error) {
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: pg.page.orientation,
+		UnitStr:        "mm",
+		Size:           gofpdf.SizeType{Wd: pg.page.size[0], Ht: pg.page.size[1]},
+	})
+	pdf.SetMargins(pg.page.margins.Left, pg.page.margins.Top, pg.page.margins.Right)
+	pdf.SetAutoPageBreak(false, pg.page.margins.Bottom) // Rows break pages themselves
+
+	// Same dates and resource order on every run, so the same input renders the same bytes. The
+	// catalog sort orders fonts by name and images by width, which registerImage makes unique.
+	pdf.SetCreationDate(pg.vars.date)
+	pdf.SetModificationDate(pg.vars.date)
+	pdf.SetCatalogSort(true)
+
+	family, err := registerFonts(pdf, families)
+	if err != nil {
+		return nil, fmt.Errorf("register fonts: %w", err)
+	}
+	pg.fontFamily = family
+
+	pdf.AddPage()
+
+	// Set default font
+	pdf.SetFont(pg.fontFamily, "", 11)
+
+	// Render template into PDF
+	if err := pg.renderTemplate(pdf, t); err != nil {
+		return nil, err
+	}
+	return pdf, nil
+}
+
 // renderTemplate renders the parsed template into the gofpdf instance.
-func (pg *PDFGenerator) renderTemplate(pdf *gofpdf.Fpdf, t *Template) {
-	// Register footer if present
+func (pg *PDFGenerator) renderTemplate(pdf *gofpdf.Fpdf, t *Template) error {
+	page := pg.page.content()
+	if err := pg.reserveRunningSpace(pdf, t); err != nil {
+		return err
+	}
+
+	// Backgrounds and the header are drawn when every page starts, below its content
+	if t.Page.Background != nil || (t.Sidebar != nil && t.Sidebar.Background != nil) || t.Header != nil {
+		pdf.SetHeaderFunc(func() {
+			pg.renderBackground(pdf, t)
+			if t.Header != nil && pdf.PageNo() > 1 {
+				pg.renderRunningRow(pdf, t.Header, pg.page.top(1))
+			}
+		})
+		pg.renderBackground(pdf, t) // The first page has already started
+	}
+
+	// The footer is drawn when every page ends, in the space reserved above the bottom margin
 	if t.Footer != nil {
 		pdf.SetFooterFunc(func() {
-			// Save current position
-			x, y := pdf.GetXY()
+			pg.renderRunningRow(pdf, t.Footer, pg.page.footerY())
+		})
+	}
 
-			// Position at 1.5 cm from bottom
-			pdf.SetY(-15)
+	if t.Sidebar == nil {
+		pg.sections = sections("", t.Rows, pg.renderRows(pdf, page, t.Rows))
+		return nil
+	}
 
-			// Render footer columns directly without checking for page breaks
-			// We assume footer fits in the margin
-			pg.renderCols(pdf, t.Footer.Height, t.Footer.Cols)
+	// The main rows and the sidebar flow independently from the top of the first page
+	main, sidebar := t.Sidebar.split(page)
+	pg.sections = sections("", t.Rows, pg.renderRows(pdf, main, t.Rows))
+	pdf.SetPage(1)
+	pdf.SetY(pg.page.top(1))
+	pg.sections = append(pg.sections, sections("sidebar, ", t.Sidebar.Rows, pg.renderRows(pdf, sidebar, t.Sidebar.Rows))...)
 
-			// Restore position
-			pdf.SetXY(x, y)
-		})
+	// Finish on the last page, so gofpdf renders its footer when closing the document
+	pdf.SetPage(pdf.PageCount())
+	return nil
+}
+
+// region is the horizontal extent of a 12-column grid on the page.
+type region struct {
+	x     float64
+	width float64
+}
+
+// colWidth returns the width of a column spanning cols of the 12 grid columns.
+func (rg region) colWidth(cols int) float64 {
+	return (rg.width / marotoCols) * float64(cols)
+}
+
+// colLayout is a column of a row positioned on the page, with its content measured.
+type colLayout struct {
+	col     Col
+	x       float64
+	width   float64
+	style   TextStyle    // Resolved style of a text column
+	lines   []string     // Wrapped lines of a text column
+	rich    []richLine   // Wrapped lines of a Markdown text column, or of text that mixes fonts
+	items   []*colLayout // Items of a list column, laid out as text columns
+	markers []bool       // Whether each item of a list column has a bullet or number
+	height  float64      // Height of the content
+}
+
+// lineCount returns the number of wrapped lines of a text or list column.
+func (l *colLayout) lineCount() int {
+	switch {
+	case l.col.List != nil:
+		n := 0
+		for _, item := range l.items {
+			n += item.lineCount()
+		}
+		return n
+	case l.col.Text != nil && l.rich != nil:
+		return len(l.rich)
 	}
+	return len(l.lines)
+}
 
-	for _, r := range t.Rows {
-		pg.renderRow(pdf, &r)
+// pageSpan is the first and last page a row is rendered on.
+type pageSpan struct {
+	first int
+	last  int
+}
+
+// renderRows renders rows one below the other in a region, starting at the current position,
+// applying their page breaks and keep rules. It returns the pages each row is rendered on.
+func (pg *PDFGenerator) renderRows(pdf *gofpdf.Fpdf, rg region, rows []Row) []pageSpan {
+	spans := make([]pageSpan, len(rows))
+	breakAfter := false
+	for i := range rows {
+		r := &rows[i]
+		if (breakAfter || strings.EqualFold(r.PageBreak, "before")) && !pg.atPageTop(pdf) {
+			pg.nextPage(pdf)
+		}
+		pg.keepRows(pdf, rg, rows[i:])
+		spans[i].first = pdf.PageNo()
+		pg.renderRow(pdf, rg, r)
+		spans[i].last = pdf.PageNo()
+		breakAfter = strings.EqualFold(r.PageBreak, "after")
 	}
+	return spans
 }
 
-// renderRow renders a single row.
-func (pg *PDFGenerator) renderRow(pdf *gofpdf.Fpdf, r *Row) {
-	// Calculate max height for the row if not specified or if content exceeds it
-	// For now, we respect the row height from YAML, but we might need to adjust based on content
-	// gofpdf flows naturally, so we might just render columns.
+// renderRow renders a single row. The row is as tall as its tallest column, or its
+// height from the template if that is larger. When the row does not fit in the remaining
+// space of the page, text and nested rows continue on the next page, while rows with
+// images or lines move to the next page whole. Rows without content end at the page bottom
+// instead, so spacers never start a page of their own.
+func (pg *PDFGenerator) renderRow(pdf *gofpdf.Fpdf, rg region, r *Row) {
+	if r.Rows != nil {
+		pg.renderGroup(pdf, rg, r)
+		return
+	}
 
-	// However, to maintain the grid layout, we need to know the Y position.
-	startY := pdf.GetY()
+	cols := pg.layoutCols(pdf, rg, r.Cols)
+	height := rowHeight(r.Height, cols)
+	pageBottom := pg.page.bottom()
 
-	// Check if we need a page break
-	// Use pdfMarginBottom to ensure we don't overlap with footer
-	if startY+r.Height > 297-pdfMarginBottom {
-		pdf.AddPage()
+	startY := pdf.GetY()
+	if startY+height > pageBottom && blank(cols) {
+		addBookmark(pdf, r)
+		pdf.SetY(max(startY, pageBottom))
+		return
+	}
+	if startY+height > pageBottom && !splittable(cols) && startY > pg.page.top(pdf.PageNo()) {
+		pg.nextPage(pdf)
 		startY = pdf.GetY()
 	}
+	startPage := pdf.PageNo()
+	addBookmark(pdf, r)
+
+	renderColBackgrounds(pdf, cols, startY, height)
+
+	// Every column flows from the top of the row; the row ends where the longest column ends
+	endPage, endY := startPage, startY
+	if startY+height <= pageBottom {
+		endY = startY + height
+	}
+	for _, l := range cols {
+		pdf.SetPage(startPage)
+		pdf.SetXY(l.x, startY)
+		pg.renderCol(pdf, l, height)
+
+		if page, y := pdf.PageNo(), pdf.GetY(); page > endPage || (page == endPage && y > endY) {
+			endPage, endY = page, y
+		}
+	}
 
-	pg.renderCols(pdf, r.Height, r.Cols)
+	// Move to the next row position
+	pdf.SetPage(endPage)
+	pdf.SetY(endY)
+}
 
-	// Move Y to the next row position
-	// If the content was taller than r.Height, we should probably use that.
-	// But for now, let's trust the template or the calculated max height.
-	// We need to calculate the max height used by columns to be safe?
-	// For now, use r.Height as before.
-	pdf.SetY(startY + r.Height)
+// renderGroup renders the rows of a group, and leaves the group at least as tall as its height.
+func (pg *PDFGenerator) renderGroup(pdf *gofpdf.Fpdf, rg region, r *Row) {
+	addBookmark(pdf, r)
+	startPage, startY := pdf.PageNo(), pdf.GetY()
+	pg.renderRows(pdf, rg, r.Rows)
+	if pdf.PageNo() == startPage && pdf.GetY() < startY+r.Height {
+		pdf.SetY(min(startY+r.Height, pg.page.bottom()))
+	}
+}
+
+// renderCol renders a column at the current position, continuing on the next pages if needed.
+func (pg *PDFGenerator) renderCol(pdf *gofpdf.Fpdf, l *colLayout, rowHeight float64) {
+	switch {
+	case l.col.Text != nil:
+		pg.renderTextFlow(pdf, l)
+	case l.col.List != nil:
+		pg.renderList(pdf, l)
+	case l.col.Rows != nil:
+		pg.renderRows(pdf, region{x: l.x, width: l.width}, l.col.Rows)
+	case l.col.Line != nil:
+		pg.renderLine(pdf, l.width, rowHeight, l.col.Line)
+	case l.col.Shape != nil:
+		pg.renderShape(pdf, l.width, rowHeight, l.col.Shape)
+	case l.col.Rating != nil:
+		pg.renderRating(pdf, l.width, rowHeight, l.col.Rating)
+	case l.col.Radar != nil:
+		pg.renderRadar(pdf, l.width, l.col.Radar)
+	case l.col.Image != nil:
+		pg.renderImage(pdf, l.width, rowHeight, l.col.Image)
+	}
+}
+
+// renderTextFlow renders the lines of a text column at the current position, continuing on the
+// next pages if needed.
+func (pg *PDFGenerator) renderTextFlow(pdf *gofpdf.Fpdf, l *colLayout) {
+	pageBottom := pg.page.bottom()
+	lineHeight := l.style.lineHeight()
+	for start, total := 0, l.lineCount(); start < total; {
+		n := min(total-start, int((pageBottom-pdf.GetY()+0.001)/lineHeight))
+		if n == 0 && pdf.GetY() <= pg.page.top(pdf.PageNo()) {
+			// Not even one line fits on an empty page, so let it overflow
+			n = 1
+		}
+		if n > 0 {
+			pg.renderText(pdf, l, start, n)
+			start += n
+		}
+		if start < total {
+			pg.nextPage(pdf)
+		}
+	}
 }
 
-// renderCols renders the columns of a row at the current Y position.
-func (pg *PDFGenerator) renderCols(pdf *gofpdf.Fpdf, rowHeight float64, cols []Col) {
+// renderCols renders the columns of a row at the current Y position without page breaks and
+// returns the height of the row. It is used for the footer, which is rendered while gofpdf closes a page.
+func (pg *PDFGenerator) renderCols(pdf *gofpdf.Fpdf, rg region, minHeight float64, cols []Col) float64 {
 	startY := pdf.GetY()
-	currentX := pdfMarginLeft
+	layouts := pg.layoutCols(pdf, rg, cols)
+	height := rowHeight(minHeight, layouts)
+	renderColBackgrounds(pdf, layouts, startY, height)
+
+	for _, l := range layouts {
+		pdf.SetXY(l.x, startY)
+		switch {
+		case l.col.Text != nil:
+			pg.renderText(pdf, l, 0, l.lineCount())
+		case l.col.List != nil:
+			pg.renderListItems(pdf, l)
+		case l.col.Rows != nil:
+			y := startY
+			for _, r := range l.col.Rows {
+				pdf.SetY(y)
+				y += pg.renderCols(pdf, region{x: l.x, width: l.width}, r.Height, r.Cols)
+			}
+		case l.col.Line != nil:
+			pg.renderLine(pdf, l.width, height, l.col.Line)
+		case l.col.Shape != nil:
+			pg.renderShape(pdf, l.width, height, l.col.Shape)
+		case l.col.Rating != nil:
+			pg.renderRating(pdf, l.width, height, l.col.Rating)
+		case l.col.Radar != nil:
+			pg.renderRadar(pdf, l.width, l.col.Radar)
+		case l.col.Image != nil:
+			pg.renderImage(pdf, l.width, height, l.col.Image)
+		}
+	}
 
-	maxHeight := rowHeight
+	return height
+}
 
-	for _, col := range cols {
-		colWidth := (a4UsableWidthMM / marotoCols) * float64(col.Width)
+// layoutCols positions the columns of a row on the 12-column grid of a region and measures their content.
+func (pg *PDFGenerator) layoutCols(pdf *gofpdf.Fpdf, rg region, cols []Col) []*colLayout {
+	layouts := make([]*colLayout, 0, len(cols))
+	currentX := rg.x
 
-		pdf.SetXY(currentX, startY)
+	for _, col := range cols {
+		l := &colLayout{
+			col:   col,
+			x:     currentX,
+			width: rg.colWidth(col.Width),
+		}
 
-		if col.Text != nil {
-			h := pg.renderText(pdf, colWidth, col.Text)
-			if h > maxHeight {
-				maxHeight = h
+		switch {
+		case col.Text != nil:
+			pg.layoutText(pdf, l)
+		case col.List != nil:
+			pg.layoutList(pdf, l)
+		case col.Rows != nil:
+			sub := region{x: l.x, width: l.width}
+			for i := range col.Rows {
+				height, _ := pg.measureRow(pdf, sub, &col.Rows[i])
+				l.height += height
 			}
-		} else if col.Line != nil {
-			pg.renderLine(pdf, colWidth, rowHeight, col.Line)
-		} else if col.Image != nil {
-			pg.renderImage(pdf, colWidth, rowHeight, col.Image)
+		case col.Shape != nil:
+			_, l.height = pg.shapeSize(pdf, l.width, col.Shape)
+		case col.Rating != nil:
+			l.height = col.Rating.size()
+		case col.Radar != nil:
+			l.height = pg.layoutRadar(l.width, col.Radar).height()
+		case col.Image != nil:
+			l.height = pg.imageHeight(pdf, l.width, col.Image)
 		}
 
-		currentX += colWidth
+		layouts = append(layouts, l)
+		currentX += l.width
 	}
+
+	return layouts
 }
 
-// renderText renders a text column.
-func (pg *PDFGenerator) renderText(pdf *gofpdf.Fpdf, width float64, p *TextProp) float64 {
-	// Set style
-	style := ""
-	if strings.Contains(strings.ToLower(p.Style), "bold") {
-		style += "B"
+// layoutText resolves the style of a text column and wraps its content to the column width.
+// Text with characters drawn in a fallback font is laid out as rich text, which mixes fonts.
+func (pg *PDFGenerator) layoutText(pdf *gofpdf.Fpdf, l *colLayout) {
+	p := l.col.Text
+	l.style = resolveTextStyle(pg.styles, p)
+	pg.setFont(pdf, l.style)
+	switch family := pg.family(l.style); {
+	case p.Markdown:
+		l.rich = pg.layoutRichText(pdf, l.style, parseMarkdown(p.Content), l.width)
+	case pg.fallsBack(family, p.Content):
+		l.rich = pg.layoutRichText(pdf, l.style, []span{{text: p.Content}}, l.width)
+	default:
+		l.lines = pdf.SplitText(pg.pdfText(family, p.Content), l.width)
 	}
-	if strings.Contains(strings.ToLower(p.Style), "italic") {
-		style += "I"
+	l.height = float64(l.lineCount()) * l.style.lineHeight()
+}
+
+// rowHeight returns the height of the tallest column of a row, at least minHeight.
+func rowHeight(minHeight float64, cols []*colLayout) float64 {
+	height := minHeight
+	for _, l := range cols {
+		height = max(height, l.height)
 	}
-	pdf.SetFont("Arial", style, float64(p.Size))
+	return height
+}
+
+// splittable reports whether a row can continue on the next page: it has no line, shape, chart,
+// image or background columns, and has text or lists over more than one line or nested rows.
+func splittable(cols []*colLayout) bool {
+	split := false
+	for _, l := range cols {
+		switch {
+		case l.col.Line != nil || l.col.Shape != nil || l.col.Rating != nil || l.col.Radar != nil ||
+			l.col.Image != nil || l.col.Background != nil:
+			return false
+		case l.col.Rows != nil || l.lineCount() > 1:
+			split = true
+		}
+	}
+	return split
+}
+
+// blank reports whether the columns of a row draw nothing, as in the rows that only add space.
+func blank(cols []*colLayout) bool {
+	for _, l := range cols {
+		c := l.col
+		if c.Background != nil || c.List != nil || c.Line != nil || c.Shape != nil || c.Rating != nil ||
+			c.Radar != nil || c.Image != nil || c.Rows != nil || (c.Text != nil && strings.TrimSpace(c.Text.Content) != "") {
+			return false
+		}
+	}
+	return true
+}
+
+// nextPage moves to the top of the next page, adding it if the current page is the last one.
+// Columns and regions that flow side by side revisit pages another column already added.
+func (pg *PDFGenerator) nextPage(pdf *gofpdf.Fpdf) {
+	if pdf.PageNo() < pdf.PageCount() {
+		pdf.SetPage(pdf.PageNo() + 1)
+	} else {
+		pdf.AddPage()
+	}
+	pdf.SetY(pg.page.top(pdf.PageNo()))
+}
+
+// setFont selects the font of a resolved text style.
+func (pg *PDFGenerator) setFont(pdf *gofpdf.Fpdf, style TextStyle) {
+	pdf.SetFont(pg.family(style), style.fontStyle(), style.Size)
+}
+
+// family returns the font family of a resolved text style.
+func (pg *PDFGenerator) family(style TextStyle) string {
+	if style.Family == "" {
+		return pg.fontFamily
+	}
+	return style.Family
+}
+
+// renderText renders n wrapped lines of a text column, from line start, at the current Y position.
+func (pg *PDFGenerator) renderText(pdf *gofpdf.Fpdf, l *colLayout, start, n int) {
+	p := l.col.Text
+	pg.setFont(pdf, l.style)
+	pdf.SetX(l.x)
 
 	// Set color
-	if p.Color != nil {
-		pdf.SetTextColor(p.Color.Red, p.Color.Green, p.Color.Blue)
+	if l.style.Color != nil {
+		pdf.SetTextColor(l.style.Color.Red, l.style.Color.Green, l.style.Color.Blue)
 	} else {
 		pdf.SetTextColor(0, 0, 0)
 	}
@@ -205,59 +617,51 @@ func (pg *PDFGenerator) renderText(pdf *gofpdf.Fpdf, width float64, p *TextProp)
 		align = "J"
 	}
 
-	// Hyperlink
+	lineHeight := l.style.lineHeight()
+
+	// Hyperlink covering the rendered lines
 	if p.Hyperlink != "" {
-		// We can add a link annotation
-		// gofpdf Link support is a bit manual.
-		// For now, let's just write the text.
-		// If we want clickable links, we need to use pdf.LinkString or similar.
-		// But MultiCell doesn't easily support mixed links.
-		// If the whole block is a link, we can do it.
-		// Let's assume the whole block is a link if Hyperlink is set.
-		linkID := pdf.AddLink()
-		pdf.SetLink(linkID, 0, -1)
-		pdf.LinkString(pdf.GetX(), pdf.GetY(), width, 5, p.Hyperlink) // Height is approximate
+		pdf.LinkString(l.x, pdf.GetY(), l.width, float64(n)*lineHeight, p.Hyperlink)
+	}
+
+	if l.rich != nil {
+		pg.renderRichLines(pdf, l, l.rich[start:start+n], align)
+		return
 	}
 
 	// Render text
 	// MultiCell(w, h, txt, border, align, fill)
-	// h is line height.
-	// Maroto default line height is around 1.0-1.2 depending on font.
-	// We use a slightly larger line height for better readability.
-	lineHeight := float64(p.Size) * 0.3527 * 1.3
-
-	// Translate text to handle special characters (e.g., tildes)
-	content := pg.tr(p.Content)
-
-	pdf.MultiCell(width, lineHeight, content, "", align, false)
-
-	// Return the height used
-	lines := pdf.SplitLines([]byte(content), width)
-	return float64(len(lines)) * lineHeight
+	// h is line height, taken from the spacing of the style.
+	// The lines are already wrapped to the column width, so MultiCell keeps them as they are.
+	pdf.MultiCell(l.width, lineHeight, strings.Join(l.lines[start:start+n], "\n"), "", align, false)
 }
 
 // renderLine renders a line column.
 func (pg *PDFGenerator) renderLine(pdf *gofpdf.Fpdf, width, height float64, p *LineProp) {
-	// Set color
-	if p.Color != nil {
-		pdf.SetDrawColor(p.Color.Red, p.Color.Green, p.Color.Blue)
+	// Set color, falling back to the color of the named style
+	color := p.Color
+	if color == nil && p.Style != "" {
+		color = pg.styles[p.Style].Color
+	}
+	if color != nil {
+		pdf.SetDrawColor(color.Red, color.Green, color.Blue)
 	} else {
 		pdf.SetDrawColor(200, 200, 200) // Default light gray
 	}
 
 	pdf.SetLineWidth(p.Thickness)
 
-	// Draw line centered vertically in the row height?
-	// Or at the top?
-	// Maroto lines are usually centered or fill the width.
-	// Let's draw a horizontal line in the middle of the height?
-	// Or maybe it's a vertical separator?
-	// Based on resume templates, it's usually a horizontal line.
-	// Let's assume horizontal line at Y + height/2
-
 	x, y := pdf.GetXY()
-	lineY := y + (height / 2)
 
+	// Vertical separators run down the middle of the column over the height of the row
+	if strings.EqualFold(p.Direction, "vertical") {
+		lineX := x + (width / 2)
+		pdf.Line(lineX, y, lineX, y+height)
+		return
+	}
+
+	// Horizontal lines run across the middle of the row
+	lineY := y + (height / 2)
 	pdf.Line(x, lineY, x+width, lineY)
 }
 
@@ -268,9 +672,15 @@ func (pg *PDFGenerator) renderImage(pdf *gofpdf.Fpdf, width, height float64, p *
 
 	x, y := pdf.GetXY()
 
-	imgWidth := width
-	if p.Percent > 0 {
-		imgWidth = width * (p.Percent / 100.0)
+	// Check if file exists
+	if _, err := os.Stat(p.Path); os.IsNotExist(err) {
+		logger.Logger().Warn("Image not found", "path", p.Path)
+		return
+	}
+
+	imgWidth, imgHeight := pg.imageSize(pdf, width, p)
+	if imgWidth == 0 {
+		return
 	}
 
 	// Center image if requested
@@ -278,24 +688,118 @@ func (pg *PDFGenerator) renderImage(pdf *gofpdf.Fpdf, width, height float64, p *
 		x += (width - imgWidth) / 2
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(p.Path); os.IsNotExist(err) {
-		logger.Logger().Warn("Image not found", "path", p.Path)
+	if isSVG(p.Path) {
+		pg.renderSVGImage(pdf, p, x, y, imgWidth)
+		return
+	}
+
+	pdf.Image(p.Path, x, y, imgWidth, imgHeight, false, "", 0, "")
+}
+
+// renderSVGImage renders an SVG image column as a PNG image, or as vector paths when the column
+// asks for them. Detailed logos drawn as paths take far more space in the PDF than their image.
+func (pg *PDFGenerator) renderSVGImage(pdf *gofpdf.Fpdf, p *ImageProp, x, y, width float64) {
+	img, err := pg.svg(p.Path)
+	if err != nil {
+		logger.Logger().Warn("Invalid SVG image", "path", p.Path, "error", err)
 		return
 	}
 
-	pdf.Image(p.Path, x, y, imgWidth, 0, false, "", 0, "")
+	if p.Vector {
+		pg.renderSVG(pdf, img, x, y, width)
+		return
+	}
+
+	dpi := p.DPI
+	if dpi <= 0 {
+		dpi = defaultSVGDPI
+	}
+	name := fmt.Sprintf("%s@%.2fmm@%ddpi", p.Path, width, dpi)
+	if info := pdf.GetImageInfo(name); info == nil {
+		data, err := rasterizeSVG(img, width, dpi)
+		if err != nil {
+			logger.Logger().Warn("Could not rasterise SVG image", "path", p.Path, "error", err)
+			return
+		}
+		registerImage(pdf, name, data)
+	}
+	pdf.ImageOptions(name, x, y, width, 0, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+}
+
+// imageHeight returns the height an image column is rendered with, or 0 if the image does not exist.
+func (pg *PDFGenerator) imageHeight(pdf *gofpdf.Fpdf, width float64, p *ImageProp) float64 {
+	_, height := pg.imageSize(pdf, width, p)
+	return height
+}
+
+// imageSize returns the size of an image in a column of the given width, keeping its aspect
+// ratio, or zeros if the image does not exist or cannot be read.
+func (pg *PDFGenerator) imageSize(pdf *gofpdf.Fpdf, width float64, p *ImageProp) (float64, float64) {
+	if _, err := os.Stat(p.Path); err != nil {
+		return 0, 0
+	}
+
+	var aspect float64
+	if isSVG(p.Path) {
+		img, err := pg.svg(p.Path)
+		if err != nil {
+			return 0, 0
+		}
+		aspect = img.height / img.width
+	} else {
+		info := registerImage(pdf, p.Path, nil)
+		if info == nil || info.Width() == 0 {
+			return 0, 0
+		}
+		aspect = info.Height() / info.Width()
+	}
+
+	w := imageWidth(width, p)
+	h := w * aspect
+	if p.MaxHeight > 0 && h > p.MaxHeight {
+		w, h = p.MaxHeight/aspect, p.MaxHeight
+	}
+	return w, h
 }
 
-// parseTemplate loads and parses the YAML template with the resume data.
+// imageWidth returns the width of an image in a column of the given width.
+func imageWidth(width float64, p *ImageProp) float64 {
+	if p.Percent > 0 {
+		return width * (p.Percent / 100.0)
+	}
+	return width
+}
+
+// templateData is the data of PDF templates: the resume data and the options of the theme.
+type templateData struct {
+	*models.ResumeData
+	Theme ThemeOptions
+}
+
+// parseTemplate loads and parses the YAML template of the theme, or of the nearest theme it
+// extends that has one, with the resume data and the theme options, together with the partials
+// of the theme.
 func (pg *PDFGenerator) parseTemplate(data *models.ResumeData) (*Template, error) {
-	tmplPath := filepath.Join(pg.templateDir, pg.theme, "resume.yaml.tmpl")
-	if _, err := os.Stat(tmplPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("template file not found: %s", tmplPath)
+	dirs, err := themeDirs(pg.templateDir, pg.theme)
+	if err != nil {
+		return nil, err
+	}
+	tmplPath, ok := findThemeFile(dirs, pdfTemplateFile)
+	if !ok {
+		return nil, fmt.Errorf("template file not found: %s", filepath.Join(pg.templateDir, pg.theme, pdfTemplateFile))
+	}
+	partials, err := themePartials(pg.templateDir, dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := resolveThemeOptions(pg.templateDir, pg.theme, pg.themeValues)
+	if err != nil {
+		return nil, err
 	}
 
-	funcs := pg.buildTemplateFuncs()
-	tmpl, err := ParseTemplate(tmplPath, data, funcs)
+	funcs := pg.buildTemplateFuncs(options)
+	tmpl, err := ParseTemplate(tmplPath, templateData{ResumeData: data, Theme: options}, funcs, partials...)
 	if err != nil {
 		return nil, err
 	}
@@ -304,24 +808,28 @@ func (pg *PDFGenerator) parseTemplate(data *models.ResumeData) (*Template, error
 }
 
 // buildTemplateFuncs creates the template.FuncMap with all available template functions.
-func (pg *PDFGenerator) buildTemplateFuncs() template.FuncMap {
+// Functions of the resume data take the template data, as templates pass them ".".
+func (pg *PDFGenerator) buildTemplateFuncs(options ThemeOptions) template.FuncMap {
 	return template.FuncMap{
 		// Data extraction
-		"getEmail":   getEmail,
-		"getPhone":   getPhone,
-		"hasSocials": hasSocials,
+		"getEmail":   func(d templateData) string { return getEmail(d.ResumeData) },
+		"getPhone":   func(d templateData) string { return getPhone(d.ResumeData) },
+		"hasSocials": func(d templateData) bool { return hasSocials(d.ResumeData) },
+		"getSocials": func(d templateData) []models.Entity { return getSocials(d.ResumeData) },
+		"theme":      options.get,
 
 		// Formatting
 		"formatDate":        formatDate,
 		"formatSkills":      formatSkills,
-		"formatCurrentDate": formatCurrentDate,
+		"topSkills":         topSkills,
+		"formatCurrentDate": pg.formatCurrentDate,
 		"escapeYAML":        escapeYAML,
 		"splitLines":        splitLines,
 		"lastURLPart":       lastURLPart,
 		"calculateHeight":   calculateHeight, // We might not need this anymore but keep for template compatibility
-		"getSocials":        getSocials,
 		"chunkSocials":      chunkSocials,
 		"assetPath":         assetPath,
+		"logoPath":          logoPath,
 	}
 }
 
@@ -345,7 +853,7 @@ func getSocialValue(data *models.ResumeData, names ...string) string {
 				if strings.EqualFold(name, "Email") {
 					return strings.TrimPrefix(social.URL, "mailto:")
 				}
-				return social.URL
+				return strings.TrimPrefix(social.URL, "tel:")
 			}
 		}
 	}
@@ -409,12 +917,20 @@ func formatDate(t *time.Time, format string) string {
 	return t.Format(format)
 }
 
-// formatCurrentDate returns the current date formatted as specified.
-func formatCurrentDate(format string) string {
-	t := time.Now()
+// formatCurrentDate returns the generation date formatted as specified.
+func (pg *PDFGenerator) formatCurrentDate(format string) string {
+	t := pg.now()
 	return formatDate(&t, format)
 }
 
+// now returns the pinned generation time, or the current time.
+func (pg *PDFGenerator) now() time.Time {
+	if !pg.timestamp.IsZero() {
+		return pg.timestamp
+	}
+	return time.Now()
+}
+
 // formatSkills formats skills as a comma-separated string.
 func formatSkills(data *models.ResumeData) string {
 	var skillStrings []string
@@ -456,9 +972,24 @@ func assetPath(path string) string {
 	return filepath.Join(wd, path)
 }
 
+// logoPath returns the absolute path of the PNG or SVG file of a logo in assets/media, preferring
+// the PNG file, or "" if the logo has no file.
+func logoPath(logo models.Logo) string {
+	if logo.Image == "" {
+		return ""
+	}
+	for _, ext := range []string{".png", ".svg"} {
+		path := assetPath(filepath.Join("assets", "media", logo.Library, logo.Image+ext))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
 // calculateHeight estimates the required height in millimeters for rendering text
 // based on font size, column width, and text content.
-// Kept for template compatibility, but logic might need adjustment for gofpdf if used in template.
+// Kept for template compatibility: rows now size to their measured content, so templates no longer need it.
 func calculateHeight(text string, fontSize int, colWidth int) float64 {
 	// This was tuned for Maroto. For gofpdf, it might be different.
 	// But since it's used in the template to set row height, we should keep it or improve it.
@@ -470,11 +1001,12 @@ func calculateHeight(text string, fontSize int, colWidth int) float64 {
 	}
 
 	// Calculate column width in millimeters
-	colWidthMM := (a4UsableWidthMM / marotoCols) * float64(colWidth)
+	// Estimated on the default page, since the template's page is not known while it executes
+	page, _ := defaultPage().layout("")
+	colWidthMM := page.content().colWidth(colWidth)
 
 	// Estimate character width
 	// Maroto used 0.55 factor. gofpdf Arial might be similar.
-	pointsToMM := 0.3527
 	charWidthFactor := 0.55
 
 	charWidthMM := float64(fontSize) * pointsToMM * charWidthFactor
@@ -1,11 +1,21 @@
 package generator
 
 import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/grafana/gofpdf"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/gobold"
 
 	"github.com/odinnordico/odinnordico.github.io/internal/models"
 )
@@ -67,3 +77,361 @@ rows:
 		assert.Error(t, err)
 	})
 }
+
+func TestPDFGenerator_GenerateWithStyles(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	themeDir := filepath.Join(tempDir, "templates", "branded")
+	if err := os.MkdirAll(filepath.Join(themeDir, "fonts"), 0755); err != nil {
+		t.Fatalf("Failed to create template dir structure: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(themeDir, "fonts", "Brand-Bold.ttf"), gobold.TTF, 0644); err != nil {
+		t.Fatalf("Failed to create font file: %v", err)
+	}
+
+	tmplContent := `
+fonts:
+  Brand:
+    bold: fonts/Brand-Bold.ttf
+styles:
+  default:
+    size: 9
+  section-heading:
+    family: Brand
+    size: 12
+    weight: bold
+    color: {red: 105, green: 190, blue: 40}
+rows:
+  - height: 8
+    cols:
+      - width: 12
+        text:
+          content: "Summary"
+          style: section-heading
+  - height: 1
+    cols:
+      - width: 12
+        line:
+          thickness: 0.5
+          style: section-heading
+  - height: 5
+    cols:
+      - width: 12
+        text:
+          content: "{{.Basic.Summary}}"
+`
+	if err := os.WriteFile(filepath.Join(themeDir, "resume.yaml.tmpl"), []byte(tmplContent), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	data := &models.ResumeData{Basic: models.BasicData{Name: "John Doe", Summary: "Engineer"}}
+
+	t.Run("Generate PDF with declared fonts and styles", func(t *testing.T) {
+		pg, err := NewPDFGenerator(outputDir, filepath.Join(tempDir, "templates"), "branded")
+		assert.NoError(t, err)
+		assert.NoError(t, pg.Generate(data, "en"))
+		assert.FileExists(t, filepath.Join(outputDir, "assets", "files", "resume.pdf"))
+	})
+
+	t.Run("Style with an unknown font family", func(t *testing.T) {
+		broken := strings.Replace(tmplContent, "family: Brand", "family: Missing", 1)
+		if err := os.WriteFile(filepath.Join(themeDir, "resume.yaml.tmpl"), []byte(broken), 0644); err != nil {
+			t.Fatalf("Failed to create template file: %v", err)
+		}
+
+		pg, err := NewPDFGenerator(outputDir, filepath.Join(tempDir, "templates"), "branded")
+		assert.NoError(t, err)
+		assert.Error(t, pg.Generate(data, "en"))
+	})
+}
+
+// newTestPDF creates a PDF page with the embedded fonts registered, as Generate does.
+func newTestPDF(t *testing.T, pg *PDFGenerator) *gofpdf.Fpdf {
+	t.Helper()
+	families, err := loadFonts("", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to load fonts: %v", err)
+	}
+
+	if pg.page, err = defaultPage().layout(""); err != nil {
+		t.Fatalf("Failed to lay out page: %v", err)
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pg.page.margins.Left, pg.page.margins.Top, pg.page.margins.Right)
+	pdf.SetAutoPageBreak(false, pg.page.margins.Bottom)
+	if pg.fontFamily, err = registerFonts(pdf, families); err != nil {
+		t.Fatalf("Failed to register fonts: %v", err)
+	}
+	pdf.AddPage()
+	return pdf
+}
+
+func TestPDFGenerator_RenderRow(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	assert.NoError(t, err)
+
+	text := func(lines int) *TextProp {
+		return &TextProp{Content: strings.TrimSuffix(strings.Repeat("line\n", lines), "\n"), Size: 10}
+	}
+	lineHeight := resolveTextStyle(nil, text(1)).lineHeight()
+	pdfMarginTop := 10.0
+	pageBottom := 297 - 10.0
+	page := region{x: 10, width: 190}
+
+	t.Run("Row grows to its tallest column", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pg.renderRow(pdf, page, &Row{Height: 5, Cols: []Col{
+			{Width: 6, Text: text(2)},
+			{Width: 6, Text: text(4)},
+		}})
+		assert.InDelta(t, pdfMarginTop+4*lineHeight, pdf.GetY(), 0.01)
+	})
+
+	t.Run("Height is a minimum", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pg.renderRow(pdf, page, &Row{Height: 30, Cols: []Col{{Width: 12, Text: text(2)}}})
+		assert.InDelta(t, pdfMarginTop+30, pdf.GetY(), 0.01)
+	})
+
+	t.Run("Text row continues on the next page", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetY(pageBottom - 2.5*lineHeight)
+		pg.renderRow(pdf, page, &Row{Cols: []Col{{Width: 12, Text: text(5)}}})
+		assert.Equal(t, 2, pdf.PageCount())
+		// Two lines fit on the first page, the other three continue on the second
+		assert.InDelta(t, pdfMarginTop+3*lineHeight, pdf.GetY(), 0.01)
+	})
+
+	t.Run("Row with a line moves to the next page whole", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetY(pageBottom - 2.5*lineHeight)
+		pg.renderRow(pdf, page, &Row{Cols: []Col{
+			{Width: 11, Text: text(5)},
+			{Width: 1, Line: &LineProp{Thickness: 0.5}},
+		}})
+		assert.Equal(t, 2, pdf.PageCount())
+		assert.InDelta(t, pdfMarginTop+5*lineHeight, pdf.GetY(), 0.01)
+	})
+
+	t.Run("Row that fits stays on the page", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetY(pageBottom - 2*lineHeight)
+		pg.renderRow(pdf, page, &Row{Cols: []Col{{Width: 12, Text: text(2)}}})
+		assert.Equal(t, 1, pdf.PageCount())
+		assert.InDelta(t, pageBottom, pdf.GetY(), 0.01)
+	})
+}
+
+func TestPDFGenerator_RenderNestedRows(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	assert.NoError(t, err)
+
+	text := func(content string) *TextProp {
+		return &TextProp{Content: content, Size: 10}
+	}
+	lineHeight := resolveTextStyle(nil, text("")).lineHeight()
+	pdfMarginTop := 10.0
+	pageBottom := 297 - 10.0
+	page := region{x: 10, width: 190}
+
+	nested := func(rows int) Col {
+		col := Col{Width: 8}
+		for i := 0; i < rows; i++ {
+			col.Rows = append(col.Rows, Row{Height: 6, Cols: []Col{
+				{Width: 8, Text: text("Company")},
+				{Width: 4, Text: text("2020 - 2024")},
+			}})
+		}
+		return col
+	}
+
+	t.Run("Nested rows stack inside the column", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pg.renderRow(pdf, page, &Row{Cols: []Col{
+			{Width: 4, Text: text("Sidebar")},
+			nested(3),
+		}})
+		assert.Equal(t, 1, pdf.PageCount())
+		assert.InDelta(t, pdfMarginTop+18, pdf.GetY(), 0.01)
+	})
+
+	t.Run("Nested rows continue on the next page", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetY(pageBottom - 13)
+		pg.renderRow(pdf, page, &Row{Cols: []Col{
+			{Width: 4, Text: text("Sidebar")},
+			nested(4),
+		}})
+		// Two nested rows fit on the first page, the other two continue on the second
+		assert.Equal(t, 2, pdf.PageCount())
+		assert.Equal(t, 2, pdf.PageNo())
+		assert.InDelta(t, pdfMarginTop+12, pdf.GetY(), 0.01)
+	})
+
+	t.Run("Measured nested height", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		cols := pg.layoutCols(pdf, page, []Col{nested(2)})
+		assert.InDelta(t, 12, cols[0].height, 0.01)
+		assert.Greater(t, 6.0, lineHeight)
+	})
+}
+
+func TestPDFGenerator_RenderSidebar(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	assert.NoError(t, err)
+
+	rows := func(n int) []Row {
+		var rows []Row
+		for i := 0; i < n; i++ {
+			rows = append(rows, Row{Height: 10, Cols: []Col{{Width: 12, Text: &TextProp{Content: "x", Size: 10}}}})
+		}
+		return rows
+	}
+
+	t.Run("Sidebar continues on the next page", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pg.renderTemplate(pdf, &Template{
+			Sidebar: &Sidebar{Width: 4, Rows: rows(40)},
+			Rows:    rows(5),
+		})
+		assert.Equal(t, 2, pdf.PageCount())
+		assert.Equal(t, 2, pdf.PageNo())
+	})
+
+	t.Run("Main rows longer than the sidebar", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pg.renderTemplate(pdf, &Template{
+			Sidebar: &Sidebar{Width: 4, Rows: rows(2)},
+			Rows:    rows(60),
+		})
+		assert.Equal(t, 3, pdf.PageCount())
+		assert.Equal(t, 3, pdf.PageNo())
+	})
+}
+
+func TestPDFGenerator_GeneratePageSize(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templateDir := filepath.Join(tempDir, "templates")
+	if err := os.MkdirAll(filepath.Join(templateDir, "default"), 0755); err != nil {
+		t.Fatalf("Failed to create template dir structure: %v", err)
+	}
+
+	tmplContent := `
+page:
+  size: A4
+  orientation: landscape
+rows:
+  - cols:
+      - width: 12
+        text:
+          content: "{{.Basic.Name}}"
+`
+	if err := os.WriteFile(filepath.Join(templateDir, "default", "resume.yaml.tmpl"), []byte(tmplContent), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+	data := &models.ResumeData{Basic: models.BasicData{Name: "John Doe"}}
+	pdfPath := filepath.Join(outputDir, "assets", "files", "resume.pdf")
+
+	tests := []struct {
+		name     string
+		opts     []PDFOption
+		mediaBox string
+		wantErr  bool
+	}{
+		{name: "Template page size", mediaBox: "/MediaBox [0 0 841.89 595.28]"},
+		{name: "Page size override", opts: []PDFOption{WithPageSize("Letter")}, mediaBox: "/MediaBox [0 0 792.00 612.00]"},
+		{name: "Invalid page size override", opts: []PDFOption{WithPageSize("huge")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, err := NewPDFGenerator(outputDir, templateDir, "default", tt.opts...)
+			assert.NoError(t, err)
+
+			err = pg.Generate(data, "en")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			content, err := os.ReadFile(pdfPath)
+			assert.NoError(t, err)
+			assert.Contains(t, string(content), tt.mediaBox)
+		})
+	}
+}
+
+func TestPDFGenerator_GenerateReproducible(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templateDir := filepath.Join(tempDir, "templates")
+	if err := os.MkdirAll(filepath.Join(templateDir, "default"), 0755); err != nil {
+		t.Fatalf("Failed to create template dir structure: %v", err)
+	}
+
+	// Icons of the same width, which gofpdf alone writes in random order
+	imagesDir := filepath.Join(tempDir, "images")
+	var imageRows strings.Builder
+	for i := range 6 {
+		pngPath := filepath.Join(imagesDir, fmt.Sprintf("icon%d.png", i))
+		writeTestPNG(t, pngPath, 16, 16, color.NRGBA{R: uint8(40 * i), G: 100, B: 200, A: 128})
+		svgPath := filepath.Join(imagesDir, fmt.Sprintf("logo%d.svg", i))
+		require.NoError(t, os.WriteFile(svgPath, []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 20 10">
+			<rect width="20" height="10" rx="2" fill="#%02x6699"/></svg>`, 40*i)), 0644))
+		fmt.Fprintf(&imageRows, "  - cols:\n      - width: 1\n        image:\n          path: %q\n      - width: 2\n        image:\n          path: %q\n", pngPath, svgPath)
+	}
+
+	tmplContent := `
+footer:
+  cols:
+    - width: 12
+      text:
+        content: "{date:January 2006} - {page} / {pages}"
+rows:
+  - cols:
+      - width: 12
+        text:
+          content: '{{.Basic.Name}} - {{formatCurrentDate "2006"}}'
+` + imageRows.String()
+	if err := os.WriteFile(filepath.Join(templateDir, "default", "resume.yaml.tmpl"), []byte(tmplContent), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+	data := &models.ResumeData{Basic: models.BasicData{Name: "John Doe"}}
+	pdfPath := filepath.Join(outputDir, "assets", "files", "resume.pdf")
+	timestamp := time.Date(2020, time.February, 3, 4, 5, 6, 0, time.UTC)
+
+	generate := func() []byte {
+		pg, err := NewPDFGenerator(outputDir, templateDir, "default", WithTimestamp(timestamp))
+		assert.NoError(t, err)
+		assert.NoError(t, pg.Generate(data, "en"))
+		content, err := os.ReadFile(pdfPath)
+		assert.NoError(t, err)
+		return content
+	}
+
+	first := generate()
+	for range 5 {
+		assert.Equal(t, first, generate(), "the same input renders byte-identical files")
+	}
+	assert.Contains(t, string(first), "/Subtype /Image")
+	assert.Contains(t, string(first), "/CreationDate (D:20200203040506")
+	assert.Contains(t, string(first), "/ModDate (D:20200203040506")
+}
+
+// writeTestPNG writes a PNG image of one color.
+func writeTestPNG(t *testing.T, path string, width, height int, c color.Color) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
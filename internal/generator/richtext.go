@@ -0,0 +1,373 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/grafana/gofpdf"
+)
+
+// span is a run of rich text in one style.
+type span struct {
+	text   string
+	bold   bool
+	italic bool
+	link   string
+}
+
+// parseMarkdown parses the inline Markdown subset of rich text columns into spans: **bold** or
+// __bold__, *italic* or _italic_, [text](url) links and backslash escapes. Newlines are kept
+// as hard line breaks. Markers without a closing marker are kept as text.
+func parseMarkdown(s string) []span {
+	p := &markdownParser{}
+	p.parse([]rune(s), span{})
+	p.flush()
+	return p.spans
+}
+
+// markdownParser accumulates the spans of a Markdown text.
+type markdownParser struct {
+	spans   []span
+	current span
+	text    strings.Builder
+}
+
+// flush ends the current span.
+func (p *markdownParser) flush() {
+	if p.text.Len() > 0 {
+		p.current.text = p.text.String()
+		p.spans = append(p.spans, p.current)
+		p.text.Reset()
+	}
+}
+
+// setStyle starts a new span when the style changes.
+func (p *markdownParser) setStyle(s span) {
+	if s != p.current {
+		p.flush()
+		p.current = s
+	}
+}
+
+func (p *markdownParser) parse(rs []rune, style span) {
+	p.setStyle(style)
+
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '\\' && i+1 < len(rs) && unicode.IsPunct(rs[i+1]):
+			i++
+			p.text.WriteRune(rs[i])
+
+		case (r == '*' || r == '_') && i+1 < len(rs) && rs[i+1] == r:
+			marker := string([]rune{r, r})
+			if style.bold || hasClosing(rs[i+2:], marker) {
+				style.bold = !style.bold
+				p.setStyle(style)
+				i++
+			} else {
+				p.text.WriteString(marker)
+				i++
+			}
+
+		case r == '*' || r == '_':
+			opening := !style.italic && i+1 < len(rs) && !unicode.IsSpace(rs[i+1]) &&
+				(r == '*' || i == 0 || !isWordRune(rs[i-1])) && hasClosing(rs[i+1:], string(r))
+			closing := style.italic && i > 0 && !unicode.IsSpace(rs[i-1]) &&
+				(r == '*' || i+1 == len(rs) || !isWordRune(rs[i+1]))
+			if opening || closing {
+				style.italic = !style.italic
+				p.setStyle(style)
+			} else {
+				p.text.WriteRune(r)
+			}
+
+		case r == '[' && style.link == "":
+			text, url, n, ok := parseLink(rs[i:])
+			if !ok {
+				p.text.WriteRune(r)
+				continue
+			}
+			linked := style
+			linked.link = url
+			p.parse(text, linked)
+			p.setStyle(style)
+			i += n - 1
+
+		default:
+			p.text.WriteRune(r)
+		}
+	}
+}
+
+// hasClosing reports whether marker appears in rs.
+func hasClosing(rs []rune, marker string) bool {
+	return strings.Contains(string(rs), marker)
+}
+
+// parseLink parses a [text](url) link at the start of rs, returning its text, its URL and its length.
+func parseLink(rs []rune) ([]rune, string, int, bool) {
+	depth := 0
+	for i, r := range rs {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(rs) || rs[i+1] != '(' {
+				return nil, "", 0, false
+			}
+			for j := i + 2; j < len(rs); j++ {
+				if rs[j] == ')' {
+					return rs[1:i], strings.TrimSpace(string(rs[i+2 : j])), j + 1, true
+				}
+			}
+			return nil, "", 0, false
+		}
+	}
+	return nil, "", 0, false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// fragment is a piece of a wrapped line of rich text rendered in one font: a word, part of a
+// word, or the space between words.
+type fragment struct {
+	text   string
+	family string
+	style  string // gofpdf font style
+	link   string
+	space  bool
+	width  float64
+}
+
+// richLine is a wrapped line of rich text.
+type richLine struct {
+	fragments []fragment
+	width     float64
+	last      bool // Last line of a paragraph, which is not justified
+}
+
+func (l *richLine) add(f fragment) {
+	l.fragments = append(l.fragments, f)
+	l.width += f.width
+}
+
+// layoutRichText wraps the spans of a rich text column into lines of the column width.
+// Lines break between words, never inside one, after CJK characters, which are not separated
+// by spaces, and at hard line breaks. Characters the font lacks are drawn in a fallback font.
+func (pg *PDFGenerator) layoutRichText(pdf *gofpdf.Fpdf, style TextStyle, spans []span, width float64) []richLine {
+	available := width - 2*pdf.GetCellMargin()
+
+	var lines []richLine
+	var line richLine
+	var word []fragment
+	var pending *fragment
+
+	// place adds the word being built to the line, wrapping first if it does not fit
+	place := func() {
+		if len(word) == 0 {
+			return
+		}
+		wordWidth := 0.0
+		for _, f := range word {
+			wordWidth += f.width
+		}
+		spaceWidth := 0.0
+		if pending != nil {
+			spaceWidth = pending.width
+		}
+		if len(line.fragments) > 0 && line.width+spaceWidth+wordWidth > available {
+			lines = append(lines, line)
+			line = richLine{}
+			pending = nil
+		}
+		if pending != nil {
+			line.add(*pending)
+			pending = nil
+		}
+		for _, f := range word {
+			line.add(f)
+		}
+		word = nil
+	}
+
+	// gofpdf writes every font change to the page, so the font only changes when it differs
+	family := pg.family(style)
+	var font, fontStyle string
+	setFont := func(f string) {
+		if f != font {
+			font = f
+			pdf.SetFont(font, fontStyle, style.Size)
+		}
+	}
+	for _, s := range spans {
+		fontStyle = richFontStyle(style, s)
+		font = family
+		pdf.SetFont(font, fontStyle, style.Size)
+
+		for _, part := range splitWords(s.text) {
+			switch part {
+			case "\n":
+				place()
+				line.last = true
+				lines = append(lines, line)
+				line = richLine{}
+				pending = nil
+			case " ":
+				place()
+				if len(line.fragments) > 0 {
+					setFont(family)
+					pending = &fragment{text: " ", family: family, style: fontStyle, link: s.link, space: true, width: pdf.GetStringWidth(" ")}
+				}
+			default:
+				for _, run := range pg.fontRuns(family, part) {
+					setFont(run.family)
+					word = append(word, fragment{text: run.text, family: run.family, style: fontStyle, link: s.link, width: pdf.GetStringWidth(run.text)})
+				}
+				if breaksAfter(part) {
+					place()
+				}
+			}
+		}
+	}
+	place()
+
+	if len(line.fragments) > 0 {
+		line.last = true
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitWords splits text into words, single " " separators for runs of spaces, and "\n" line
+// breaks. Each CJK character is a word of its own.
+func splitWords(text string) []string {
+	var parts []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			parts = append(parts, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case r == '\n':
+			flush()
+			parts = append(parts, "\n")
+		case unicode.IsSpace(r):
+			flush()
+			if len(parts) == 0 || parts[len(parts)-1] != " " {
+				parts = append(parts, " ")
+			}
+		case isCJK(r):
+			flush()
+			parts = append(parts, string(r))
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return parts
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character, which lines may break after.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// breaksAfter reports whether a line may break after a word without a space, as after a CJK character.
+func breaksAfter(word string) bool {
+	r, _ := utf8.DecodeLastRuneInString(word)
+	return isCJK(r)
+}
+
+// richFontStyle combines the weight of the column style with the emphasis of a span.
+func richFontStyle(style TextStyle, s span) string {
+	base := style.fontStyle()
+	result := ""
+	if s.bold || strings.Contains(base, "B") {
+		result += "B"
+	}
+	if s.italic || strings.Contains(base, "I") {
+		result += "I"
+	}
+	return result
+}
+
+// renderRichLines renders wrapped lines of rich text at the current Y position. Links are
+// underlined, and every run of linked words on a line gets its own clickable area.
+func (pg *PDFGenerator) renderRichLines(pdf *gofpdf.Fpdf, l *colLayout, lines []richLine, align string) {
+	cellMargin := pdf.GetCellMargin()
+	pdf.SetCellMargin(0)
+	defer pdf.SetCellMargin(cellMargin)
+
+	lineHeight := l.style.lineHeight()
+	available := l.width - 2*cellMargin
+	y := pdf.GetY()
+
+	for _, line := range lines {
+		x := l.x + cellMargin
+		extra := 0.0
+		switch align {
+		case "R":
+			x += available - line.width
+		case "C":
+			x += (available - line.width) / 2
+		case "J":
+			if spaces := countSpaces(line); !line.last && spaces > 0 {
+				extra = (available - line.width) / float64(spaces)
+			}
+		}
+
+		linkX, link := 0.0, ""
+		for _, f := range line.fragments {
+			if f.link != link {
+				if link != "" {
+					pdf.LinkString(linkX, y, x-linkX, lineHeight, link)
+				}
+				linkX, link = x, f.link
+			}
+
+			width := f.width
+			if f.space {
+				width += extra
+			} else {
+				fontStyle := f.style
+				if f.link != "" {
+					fontStyle += "U"
+				}
+				pdf.SetFont(f.family, fontStyle, l.style.Size)
+				pdf.SetXY(x, y)
+				pdf.CellFormat(f.width, lineHeight, f.text, "", 0, "L", false, 0, "")
+			}
+			x += width
+		}
+		if link != "" {
+			pdf.LinkString(linkX, y, x-linkX, lineHeight, link)
+		}
+
+		y += lineHeight
+	}
+
+	pg.setFont(pdf, l.style)
+	pdf.SetXY(l.x, y)
+}
+
+func countSpaces(line richLine) int {
+	n := 0
+	for _, f := range line.fragments {
+		if f.space {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []span
+	}{
+		{
+			name: "Plain text",
+			text: "Just text",
+			want: []span{{text: "Just text"}},
+		},
+		{
+			name: "Bold and italic",
+			text: "Go **and** *Rust*, __bold__ _italic_",
+			want: []span{
+				{text: "Go "}, {text: "and", bold: true}, {text: " "}, {text: "Rust", italic: true},
+				{text: ", "}, {text: "bold", bold: true}, {text: " "}, {text: "italic", italic: true},
+			},
+		},
+		{
+			name: "Nested emphasis",
+			text: "**bold *both***",
+			want: []span{{text: "bold ", bold: true}, {text: "both", bold: true, italic: true}},
+		},
+		{
+			name: "Link with emphasis",
+			text: "See [my **blog**](https://example.com) now",
+			want: []span{
+				{text: "See "}, {text: "my ", link: "https://example.com"},
+				{text: "blog", bold: true, link: "https://example.com"}, {text: " now"},
+			},
+		},
+		{
+			name: "Literal markers",
+			text: `snake_case_name, 2 * 3, \*escaped\*, [not a link] and **unclosed`,
+			want: []span{{text: "snake_case_name, 2 * 3, *escaped*, [not a link] and **unclosed"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseMarkdown(tt.text))
+		})
+	}
+}
+
+func TestPDFGenerator_LayoutRichText(t *testing.T) {
+	pg := &PDFGenerator{}
+	pdf := newTestPDF(t, pg)
+	style := TextStyle{Size: 10, Spacing: defaultLineSpacing}
+
+	spans := parseMarkdown("Built **fast** services with a [long linked phrase that wraps](https://example.com) in Go.\nSecond paragraph")
+	lines := pg.layoutRichText(pdf, style, spans, 50)
+	require.Greater(t, len(lines), 2)
+
+	available := 50 - 2*pdf.GetCellMargin()
+	linkedLines := 0
+	for i, line := range lines {
+		assert.LessOrEqual(t, line.width, available+0.001, "line %d is wider than the column", i)
+		assert.False(t, line.fragments[0].space, "line %d starts with a space", i)
+		assert.False(t, line.fragments[len(line.fragments)-1].space, "line %d ends with a space", i)
+
+		for _, f := range line.fragments {
+			if f.link != "" {
+				linkedLines++
+				break
+			}
+		}
+	}
+
+	assert.Greater(t, linkedLines, 1, "the link should wrap over several lines")
+	assert.Equal(t, "Second paragraph", joinFragments(lines[len(lines)-1]))
+	assert.True(t, lines[len(lines)-2].last, "the line before a hard break ends a paragraph")
+	assert.Equal(t, "B", lines[0].fragments[2].style)
+}
+
+func TestSplitWords(t *testing.T) {
+	assert.Equal(t, []string{"Hello", " ", "world", "\n", "Go", "言", "語", " ", "ok"}, splitWords("Hello  world\nGo言語 ok"))
+	assert.True(t, breaksAfter("言"))
+	assert.False(t, breaksAfter("Go"))
+}
+
+func joinFragments(line richLine) string {
+	var b strings.Builder
+	for _, f := range line.fragments {
+		b.WriteString(f.text)
+	}
+	return b.String()
+}
+
+func TestPDFGenerator_GenerateMarkdown(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templateDir := filepath.Join(tempDir, "templates")
+	require.NoError(t, os.MkdirAll(filepath.Join(templateDir, "default"), 0755))
+
+	tmplContent := `
+rows:
+  - cols:
+      - width: 4
+        text:
+          content: "{{escapeYAML .Basic.Summary}}"
+          align: justify
+          markdown: true
+`
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "default", "resume.yaml.tmpl"), []byte(tmplContent), 0644))
+
+	pg, err := NewPDFGenerator(outputDir, templateDir, "default")
+	require.NoError(t, err)
+
+	data := &models.ResumeData{
+		Basic: models.BasicData{
+			Summary: "I build **reliable** systems and write about them on [my engineering blog with many words](https://example.com/blog).",
+		},
+	}
+	require.NoError(t, pg.Generate(data, "en"))
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "assets", "files", "resume.pdf"))
+	require.NoError(t, err)
+	// The link wraps, so it has one clickable area per line
+	assert.Greater(t, bytes.Count(content, []byte("(https://example.com/blog)")), 1)
+}
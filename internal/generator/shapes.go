@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grafana/gofpdf"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/logger"
+)
+
+const (
+	// defaultBorderWidth is the width of shape borders without a border width, in millimeters.
+	defaultBorderWidth = 0.2
+	// defaultShapePadding is the space around the label of a shape without a padding, in millimeters.
+	defaultShapePadding = 1.0
+)
+
+// checkShapes verifies the direction of lines and the alignment and size of shapes.
+func (t *Template) checkShapes() error {
+	var err error
+	t.walkCols(func(path string, col *Col) bool {
+		if col.Line != nil {
+			switch strings.ToLower(col.Line.Direction) {
+			case "", "horizontal", "vertical":
+			default:
+				err = fmt.Errorf("%s: line direction must be horizontal or vertical, got %q", path, col.Line.Direction)
+			}
+		}
+		if s := col.Shape; s != nil {
+			switch strings.ToLower(s.Align) {
+			case "", "left", "center", "right":
+			default:
+				err = fmt.Errorf("%s: shape align must be left, center or right, got %q", path, s.Align)
+			}
+			if s.Width < 0 || s.Height < 0 || s.Radius < 0 || s.Padding < 0 || s.BorderWidth < 0 {
+				err = fmt.Errorf("%s: shape sizes cannot be negative", path)
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// renderBackground fills the current page with the page background and the sidebar shading.
+// It runs when a page starts, so the content of the page is drawn over it.
+func (pg *PDFGenerator) renderBackground(pdf *gofpdf.Fpdf, t *Template) {
+	if bg := t.Page.Background; bg != nil {
+		if bg.Color != nil {
+			setFillColor(pdf, bg.Color)
+			pdf.Rect(0, 0, pg.page.width, pg.page.height, "F")
+		}
+		if bg.Image != "" {
+			if _, err := os.Stat(bg.Image); err != nil {
+				logger.Logger().Warn("Background image not found", "path", bg.Image)
+			} else if registerImage(pdf, bg.Image, nil) != nil {
+				pdf.Image(bg.Image, 0, 0, pg.page.width, pg.page.height, false, "", 0, "")
+			}
+		}
+	}
+
+	if t.Sidebar != nil && t.Sidebar.Background != nil {
+		_, sidebar := t.Sidebar.split(pg.page.content())
+		x, width := 0.0, sidebar.x+sidebar.width+t.Sidebar.Gap/2
+		if strings.EqualFold(t.Sidebar.Side, "right") {
+			x = sidebar.x - t.Sidebar.Gap/2
+			width = pg.page.width - x
+		}
+		setFillColor(pdf, t.Sidebar.Background)
+		pdf.Rect(x, 0, width, pg.page.height, "F")
+	}
+}
+
+// renderColBackgrounds fills the columns with a background over the height of the row.
+func renderColBackgrounds(pdf *gofpdf.Fpdf, cols []*colLayout, y, height float64) {
+	for _, l := range cols {
+		if l.col.Background != nil {
+			setFillColor(pdf, l.col.Background)
+			pdf.Rect(l.x, y, l.width, height, "F")
+		}
+	}
+}
+
+// shapeSize returns the size of a shape in a column of the given width. A height of 0 means
+// the shape is as tall as its row.
+func (pg *PDFGenerator) shapeSize(pdf *gofpdf.Fpdf, width float64, s *ShapeProp) (float64, float64) {
+	w, h := s.Width, s.Height
+	if s.Label != nil {
+		style := resolveTextStyle(pg.styles, s.Label)
+		if w == 0 {
+			pg.setFont(pdf, style)
+			w = pdf.GetStringWidth(pg.pdfText(pg.family(style), s.Label.Content)) + 2*s.padding()
+		}
+		if h == 0 {
+			h = style.lineHeight() + 2*s.padding()
+		}
+	}
+	if w == 0 || w > width {
+		w = width
+	}
+	return w, h
+}
+
+func (s *ShapeProp) padding() float64 {
+	if s.Padding > 0 {
+		return s.Padding
+	}
+	return defaultShapePadding
+}
+
+// renderShape renders a shape column at the current position and its label centered in it.
+func (pg *PDFGenerator) renderShape(pdf *gofpdf.Fpdf, width, rowHeight float64, s *ShapeProp) {
+	x, y := pdf.GetXY()
+	w, h := pg.shapeSize(pdf, width, s)
+	if h == 0 {
+		h = rowHeight
+	}
+
+	switch strings.ToLower(s.Align) {
+	case "center":
+		x += (width - w) / 2
+	case "right":
+		x += width - w
+	}
+
+	op := ""
+	if s.Fill != nil {
+		setFillColor(pdf, s.Fill)
+		op += "F"
+	}
+	if s.Border != nil {
+		pdf.SetDrawColor(s.Border.Red, s.Border.Green, s.Border.Blue)
+		borderWidth := s.BorderWidth
+		if borderWidth == 0 {
+			borderWidth = defaultBorderWidth
+		}
+		pdf.SetLineWidth(borderWidth)
+		op += "D"
+	}
+
+	if op != "" {
+		if s.Radius > 0 {
+			pdf.RoundedRect(x, y, w, h, min(s.Radius, w/2, h/2), "1234", op)
+		} else {
+			pdf.Rect(x, y, w, h, op)
+		}
+	}
+
+	if s.Label != nil {
+		style := resolveTextStyle(pg.styles, s.Label)
+		pg.setFont(pdf, style)
+		if style.Color != nil {
+			pdf.SetTextColor(style.Color.Red, style.Color.Green, style.Color.Blue)
+		} else {
+			pdf.SetTextColor(0, 0, 0)
+		}
+		pdf.SetXY(x, y)
+		pdf.CellFormat(w, h, pg.pdfText(pg.family(style), s.Label.Content), "", 0, "CM", false, 0, s.Label.Hyperlink)
+	}
+
+	pdf.SetXY(x, y+h)
+}
+
+func setFillColor(pdf *gofpdf.Fpdf, c *Color) {
+	pdf.SetFillColor(c.Red, c.Green, c.Blue)
+}
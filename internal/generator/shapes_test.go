@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_CheckShapes(t *testing.T) {
+	col := func(c Col) Template {
+		c.Width = 12
+		return Template{Rows: []Row{{Cols: []Col{c}}}}
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    Template
+		wantErr bool
+	}{
+		{name: "Vertical line", tmpl: col(Col{Line: &LineProp{Direction: "vertical"}})},
+		{name: "Pill", tmpl: col(Col{Shape: &ShapeProp{Radius: 2, Align: "center", Label: &TextProp{Content: "Go"}}})},
+		{name: "Unknown line direction", tmpl: col(Col{Line: &LineProp{Direction: "diagonal"}}), wantErr: true},
+		{name: "Unknown shape alignment", tmpl: col(Col{Shape: &ShapeProp{Align: "middle"}}), wantErr: true},
+		{name: "Negative shape size", tmpl: col(Col{Shape: &ShapeProp{Height: -1}}), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tmpl.checkShapes()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPDFGenerator_ShapeSize(t *testing.T) {
+	pg := &PDFGenerator{}
+	pdf := newTestPDF(t, pg)
+
+	w, h := pg.shapeSize(pdf, 50, &ShapeProp{})
+	assert.Equal(t, 50.0, w, "shapes fill the column by default")
+	assert.Equal(t, 0.0, h, "shapes fill the row by default")
+
+	label := &TextProp{Content: "Kubernetes", Size: 8}
+	w, h = pg.shapeSize(pdf, 50, &ShapeProp{Padding: 2, Label: label})
+	pg.setFont(pdf, resolveTextStyle(nil, label))
+	assert.InDelta(t, pdf.GetStringWidth("Kubernetes")+4, w, 0.01, "pills fit their label")
+	assert.InDelta(t, resolveTextStyle(nil, label).lineHeight()+4, h, 0.01)
+
+	w, _ = pg.shapeSize(pdf, 10, &ShapeProp{Width: 30})
+	assert.Equal(t, 10.0, w, "shapes do not overflow their column")
+}
+
+func TestPDFGenerator_RenderBackgrounds(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	require.NoError(t, err)
+
+	t.Run("Row with a column background moves to the next page whole", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetY(pg.page.bottom() - 5)
+		text := &TextProp{Content: "one\ntwo\nthree", Size: 10}
+		pg.renderRow(pdf, pg.page.content(), &Row{Cols: []Col{{Width: 12, Background: &Color{Red: 240, Green: 240, Blue: 240}, Text: text}}})
+		assert.Equal(t, 2, pdf.PageCount())
+		assert.InDelta(t, pg.page.top(1)+3*resolveTextStyle(nil, text).lineHeight(), pdf.GetY(), 0.01)
+	})
+
+	t.Run("Page background on every page", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetCompression(false)
+
+		var rows []Row
+		for i := 0; i < 60; i++ {
+			rows = append(rows, Row{Height: 10, Cols: []Col{{Width: 12, Text: &TextProp{Content: "x"}}}})
+		}
+		pg.renderTemplate(pdf, &Template{
+			Page: Page{Background: &Background{Color: &Color{Red: 250, Green: 248, Blue: 240}}},
+			Rows: rows,
+		})
+		require.Equal(t, 3, pdf.PageCount())
+
+		var out bytes.Buffer
+		require.NoError(t, pdf.Output(&out))
+		// A4 page filled from its top left corner, in points
+		assert.Equal(t, 3, bytes.Count(out.Bytes(), []byte("0.00 841.89 595.28 -841.89 re f")))
+	})
+}
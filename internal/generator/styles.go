@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// defaultStyleName is the named style applied to every text column before its own style.
+	defaultStyleName = "default"
+	// defaultFontSize is the font size of text that neither its column nor its style sizes.
+	defaultFontSize = 10
+	// defaultLineSpacing is the line height, as a multiple of the font size, of text without a spacing.
+	defaultLineSpacing = 1.3
+	// pointsToMM converts font sizes in points to millimeters.
+	pointsToMM = 0.3527
+)
+
+// resolveTextStyle applies the default style, the named style of the text column and its explicit
+// properties, in that order. A style that is not a named style is the font weight of the text.
+func resolveTextStyle(styles map[string]TextStyle, p *TextProp) TextStyle {
+	s := TextStyle{Size: defaultFontSize, Spacing: defaultLineSpacing}
+	s = s.merge(styles[defaultStyleName])
+
+	if named, ok := styles[p.Style]; ok {
+		s = s.merge(named)
+	} else if p.Style != "" {
+		s.Weight = p.Style
+	}
+
+	if p.Size > 0 {
+		s.Size = p.Size
+	}
+	if p.Color != nil {
+		s.Color = p.Color
+	}
+	return s
+}
+
+// merge returns s with the properties set in o overriding its own.
+func (s TextStyle) merge(o TextStyle) TextStyle {
+	if o.Family != "" {
+		s.Family = o.Family
+	}
+	if o.Size > 0 {
+		s.Size = o.Size
+	}
+	if o.Weight != "" {
+		s.Weight = o.Weight
+	}
+	if o.Color != nil {
+		s.Color = o.Color
+	}
+	if o.Spacing > 0 {
+		s.Spacing = o.Spacing
+	}
+	return s
+}
+
+// fontStyle converts the weight of a style to the gofpdf style string.
+func (s TextStyle) fontStyle() string {
+	weight := strings.ToLower(s.Weight)
+	style := ""
+	if strings.Contains(weight, "bold") {
+		style += "B"
+	}
+	if strings.Contains(weight, "italic") {
+		style += "I"
+	}
+	return style
+}
+
+// lineHeight returns the height of a line of text in millimeters.
+func (s TextStyle) lineHeight() float64 {
+	return s.Size * pointsToMM * s.Spacing
+}
+
+// isWeight reports whether s is a font weight rather than the name of a style.
+func isWeight(s string) bool {
+	switch strings.ToLower(s) {
+	case "", "normal", "regular":
+		return true
+	}
+	return TextStyle{Weight: s}.fontStyle() != ""
+}
+
+// checkStyles verifies that the styles of the template are valid and that every style
+// referenced by a column is either a named style or a font weight.
+func (t *Template) checkStyles() error {
+	names := make([]string, 0, len(t.Styles))
+	for name := range t.Styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		style := t.Styles[name]
+		if !isWeight(style.Weight) {
+			return fmt.Errorf("style %s: unknown weight %q", name, style.Weight)
+		}
+	}
+
+	var err error
+	t.walkCols(func(path string, col *Col) bool {
+		if col.Text != nil {
+			if _, ok := t.Styles[col.Text.Style]; !ok && !isWeight(col.Text.Style) {
+				err = fmt.Errorf("%s: unknown text style %q", path, col.Text.Style)
+			}
+		}
+		if col.List != nil {
+			if _, ok := t.Styles[col.List.Style]; !ok && !isWeight(col.List.Style) {
+				err = fmt.Errorf("%s: unknown list style %q", path, col.List.Style)
+			}
+		}
+		if col.Shape != nil && col.Shape.Label != nil {
+			if _, ok := t.Styles[col.Shape.Label.Style]; !ok && !isWeight(col.Shape.Label.Style) {
+				err = fmt.Errorf("%s: unknown label style %q", path, col.Shape.Label.Style)
+			}
+		}
+		if col.Line != nil && col.Line.Style != "" {
+			if _, ok := t.Styles[col.Line.Style]; !ok {
+				err = fmt.Errorf("%s: unknown line style %q", path, col.Line.Style)
+			}
+		}
+		if col.Rating != nil && col.Rating.Style != "" {
+			if _, ok := t.Styles[col.Rating.Style]; !ok {
+				err = fmt.Errorf("%s: unknown rating style %q", path, col.Rating.Style)
+			}
+		}
+		if r := col.Radar; r != nil {
+			if _, ok := t.Styles[r.Style]; r.Style != "" && !ok {
+				err = fmt.Errorf("%s: unknown radar style %q", path, r.Style)
+			}
+			if r.Label != nil {
+				if _, ok := t.Styles[r.Label.Style]; !ok && !isWeight(r.Label.Style) {
+					err = fmt.Errorf("%s: unknown radar label style %q", path, r.Label.Style)
+				}
+			}
+		}
+		return err == nil
+	})
+
+	return err
+}
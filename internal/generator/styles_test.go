@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveTextStyle(t *testing.T) {
+	accent := &Color{Red: 105, Green: 190, Blue: 40}
+	gray := &Color{Red: 128, Green: 128, Blue: 128}
+	styles := map[string]TextStyle{
+		"default":         {Family: "Inter", Size: 9},
+		"section-heading": {Size: 12, Weight: "bold", Color: accent, Spacing: 1.5},
+	}
+
+	tests := []struct {
+		name string
+		text TextProp
+		want TextStyle
+	}{
+		{
+			name: "Default style",
+			text: TextProp{},
+			want: TextStyle{Family: "Inter", Size: 9, Spacing: defaultLineSpacing},
+		},
+		{
+			name: "Named style",
+			text: TextProp{Style: "section-heading"},
+			want: TextStyle{Family: "Inter", Size: 12, Weight: "bold", Color: accent, Spacing: 1.5},
+		},
+		{
+			name: "Explicit properties override the named style",
+			text: TextProp{Style: "section-heading", Size: 14, Color: gray},
+			want: TextStyle{Family: "Inter", Size: 14, Weight: "bold", Color: gray, Spacing: 1.5},
+		},
+		{
+			name: "Weight instead of a named style",
+			text: TextProp{Style: "italic", Size: 10},
+			want: TextStyle{Family: "Inter", Size: 10, Weight: "italic", Spacing: defaultLineSpacing},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveTextStyle(styles, &tt.text))
+		})
+	}
+
+	t.Run("Without styles", func(t *testing.T) {
+		got := resolveTextStyle(nil, &TextProp{Style: "bolditalic"})
+		assert.Equal(t, TextStyle{Size: defaultFontSize, Weight: "bolditalic", Spacing: defaultLineSpacing}, got)
+		assert.Equal(t, "BI", got.fontStyle())
+	})
+}
+
+func TestTemplate_CheckStyles(t *testing.T) {
+	text := func(style string) Row {
+		return Row{Cols: []Col{{Width: 12, Text: &TextProp{Content: "x", Style: style}}}}
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    Template
+		wantErr bool
+	}{
+		{
+			name: "Weights and named styles",
+			tmpl: Template{
+				Styles: map[string]TextStyle{"heading": {Weight: "bold"}},
+				Rows:   []Row{text(""), text("normal"), text("bolditalic"), text("heading")},
+				Footer: &Row{Cols: []Col{{Width: 12, Line: &LineProp{Style: "heading"}}}},
+			},
+		},
+		{
+			name: "Unknown text style in nested rows",
+			tmpl: Template{
+				Sidebar: &Sidebar{Width: 4, Rows: []Row{{Cols: []Col{{Width: 12, Rows: []Row{text("heading")}}}}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown text style",
+			tmpl:    Template{Rows: []Row{text("heading")}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown list style",
+			tmpl:    Template{Rows: []Row{{Cols: []Col{{Width: 12, List: &ListProp{Items: []string{"x"}, Style: "heading"}}}}}},
+			wantErr: true,
+		},
+		{
+			name: "Unknown line style in footer",
+			tmpl: Template{
+				Footer: &Row{Cols: []Col{{Width: 12, Line: &LineProp{Style: "accent"}}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown weight",
+			tmpl: Template{
+				Styles: map[string]TextStyle{"heading": {Weight: "heavy"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tmpl.checkStyles()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
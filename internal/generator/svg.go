@@ -0,0 +1,1192 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/gofpdf"
+	"golang.org/x/image/vector"
+)
+
+// defaultSVGDPI is the resolution SVG images are rasterised at when their column sets no dpi and
+// does not ask for vector paths.
+const defaultSVGDPI = 300
+
+// svgImage is an SVG file parsed into filled and stroked paths in the coordinates of its view box.
+type svgImage struct {
+	minX, minY    float64
+	width, height float64
+	shapes        []svgShape
+}
+
+// svgShape is a painted path of an SVG image.
+type svgShape struct {
+	path        []svgSegment
+	fill        *Color
+	evenOdd     bool
+	stroke      *Color
+	strokeWidth float64
+	opacity     float64 // Fill and stroke opacity
+}
+
+// svgSegment is a path segment with absolute, transformed points: M and L have one point,
+// C has two control points and an end point, and Z has none.
+type svgSegment struct {
+	op  byte
+	pts [3]svgPoint
+}
+
+type svgPoint struct{ x, y float64 }
+
+// svgMatrix is an affine transform [a b c d e f]: x' = a*x + c*y + e, y' = b*x + d*y + f.
+type svgMatrix [6]float64
+
+var svgIdentity = svgMatrix{1, 0, 0, 1, 0, 0}
+
+func (m svgMatrix) mul(n svgMatrix) svgMatrix {
+	return svgMatrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m svgMatrix) apply(p svgPoint) svgPoint {
+	return svgPoint{m[0]*p.x + m[2]*p.y + m[4], m[1]*p.x + m[3]*p.y + m[5]}
+}
+
+// scale returns the average scale factor of the transform, used for stroke widths.
+func (m svgMatrix) scale() float64 {
+	return math.Sqrt(math.Abs(m[0]*m[3] - m[1]*m[2]))
+}
+
+// svgState is the inherited painting state of an SVG element.
+type svgState struct {
+	props     map[string]string
+	transform svgMatrix
+	opacity   float64
+	hidden    bool
+}
+
+// svgContainers lists the elements whose content is not rendered in place.
+var svgContainers = map[string]bool{
+	"defs": true, "clipPath": true, "mask": true, "symbol": true, "pattern": true, "marker": true,
+	"metadata": true, "namedview": true, "title": true, "desc": true, "text": true,
+	"linearGradient": true, "radialGradient": true,
+}
+
+// loadSVG parses an SVG file. It supports paths, basic shapes, groups, transforms, solid
+// fills and strokes set by attributes, inline styles or class rules, and gradients, which are
+// painted with the color of their first stop.
+func loadSVG(path string) (*svgImage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	img, err := parseSVG(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse SVG %s: %w", path, err)
+	}
+	return img, nil
+}
+
+func parseSVG(data []byte) (*svgImage, error) {
+	rules := svgStyleRules(data)
+	gradients := svgGradients(data)
+
+	img := &svgImage{}
+	stack := []svgState{{props: map[string]string{}, transform: svgIdentity, opacity: 1}}
+	root := true
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			parent := stack[len(stack)-1]
+			attrs := svgAttrs(el)
+			state := parent.inherit(el.Name.Local, attrs, rules)
+
+			if root {
+				if el.Name.Local != "svg" {
+					return nil, fmt.Errorf("root element is %s, not svg", el.Name.Local)
+				}
+				if err := img.setViewport(attrs); err != nil {
+					return nil, err
+				}
+				root = false
+			}
+			if svgContainers[el.Name.Local] {
+				state.hidden = true
+			}
+
+			if !state.hidden {
+				if path := svgElementPath(el.Name.Local, attrs); path != nil {
+					img.addShape(path, state, gradients)
+				}
+			}
+			stack = append(stack, state)
+
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	if root {
+		return nil, fmt.Errorf("no svg element")
+	}
+	return img, nil
+}
+
+// setViewport reads the view box of the image, falling back to its width and height.
+func (img *svgImage) setViewport(attrs map[string]string) error {
+	if vb := svgNumbers(attrs["viewBox"]); len(vb) == 4 && vb[2] > 0 && vb[3] > 0 {
+		img.minX, img.minY, img.width, img.height = vb[0], vb[1], vb[2], vb[3]
+		return nil
+	}
+	img.width, _ = svgLength(attrs["width"])
+	img.height, _ = svgLength(attrs["height"])
+	if img.width <= 0 || img.height <= 0 {
+		return fmt.Errorf("svg element has neither a viewBox nor a width and height")
+	}
+	return nil
+}
+
+// inherit returns the state of a child element with the given attributes.
+func (s svgState) inherit(name string, attrs map[string]string, rules map[string]map[string]string) svgState {
+	props := make(map[string]string, len(s.props))
+	for _, k := range []string{"fill", "fill-rule", "fill-opacity", "stroke", "stroke-width", "stroke-opacity"} {
+		if v, ok := s.props[k]; ok {
+			props[k] = v
+		}
+	}
+
+	// Presentation attributes, then style rules, then the inline style
+	for k, v := range attrs {
+		props[k] = v
+	}
+	for _, selector := range append([]string{name}, svgSelectors(attrs)...) {
+		for k, v := range rules[selector] {
+			props[k] = v
+		}
+	}
+	for k, v := range svgDeclarations(attrs["style"]) {
+		props[k] = v
+	}
+
+	child := svgState{props: props, transform: s.transform, opacity: s.opacity, hidden: s.hidden}
+	if t, ok := attrs["transform"]; ok {
+		child.transform = s.transform.mul(parseSVGTransform(t))
+	}
+	if v, err := strconv.ParseFloat(props["opacity"], 64); err == nil {
+		child.opacity *= v
+	}
+	delete(props, "opacity")
+	if props["display"] == "none" || props["visibility"] == "hidden" {
+		child.hidden = true
+	}
+	return child
+}
+
+// addShape adds a painted path to the image.
+func (img *svgImage) addShape(path []svgSegment, state svgState, gradients map[string]Color) {
+	shape := svgShape{opacity: state.opacity, evenOdd: state.props["fill-rule"] == "evenodd"}
+
+	fill, ok := state.props["fill"]
+	if !ok {
+		fill = "black"
+	}
+	shape.fill = parseSVGColor(fill, gradients)
+	shape.stroke = parseSVGColor(state.props["stroke"], gradients)
+	if shape.stroke != nil {
+		shape.strokeWidth = 1
+		if w, ok := svgLength(state.props["stroke-width"]); ok {
+			shape.strokeWidth = w
+		}
+		shape.strokeWidth *= state.transform.scale()
+	}
+	if v, err := strconv.ParseFloat(state.props["fill-opacity"], 64); err == nil && shape.fill != nil {
+		shape.opacity *= v
+	}
+	if shape.fill == nil && shape.stroke == nil {
+		return
+	}
+
+	for i, seg := range path {
+		for j := range seg.pts {
+			path[i].pts[j] = state.transform.apply(seg.pts[j])
+		}
+	}
+	shape.path = path
+	img.shapes = append(img.shapes, shape)
+}
+
+func svgAttrs(el xml.StartElement) map[string]string {
+	attrs := make(map[string]string, len(el.Attr))
+	for _, a := range el.Attr {
+		attrs[a.Name.Local] = strings.TrimSpace(a.Value)
+	}
+	return attrs
+}
+
+// svgSelectors returns the class and id selectors matching an element.
+func svgSelectors(attrs map[string]string) []string {
+	var selectors []string
+	for _, class := range strings.Fields(attrs["class"]) {
+		selectors = append(selectors, "."+class)
+	}
+	if id := attrs["id"]; id != "" {
+		selectors = append(selectors, "#"+id)
+	}
+	return selectors
+}
+
+var svgStyleElement = regexp.MustCompile(`(?s)<style[^>]*>(.*?)</style>`)
+
+// svgStyleRules parses the rules of the style elements of an SVG document by selector. Only
+// simple element, class and id selectors are supported.
+func svgStyleRules(data []byte) map[string]map[string]string {
+	rules := map[string]map[string]string{}
+	for _, m := range svgStyleElement.FindAllSubmatch(data, -1) {
+		css := strings.ReplaceAll(strings.ReplaceAll(string(m[1]), "<![CDATA[", ""), "]]>", "")
+		for _, rule := range strings.Split(css, "}") {
+			selectors, body, ok := strings.Cut(rule, "{")
+			if !ok {
+				continue
+			}
+			decls := svgDeclarations(body)
+			for _, sel := range strings.Split(selectors, ",") {
+				sel = strings.TrimSpace(sel)
+				if rules[sel] == nil {
+					rules[sel] = map[string]string{}
+				}
+				for k, v := range decls {
+					rules[sel][k] = v
+				}
+			}
+		}
+	}
+	return rules
+}
+
+// svgDeclarations parses CSS declarations such as "fill:#fff;stroke:none".
+func svgDeclarations(s string) map[string]string {
+	decls := map[string]string{}
+	for _, decl := range strings.Split(s, ";") {
+		k, v, ok := strings.Cut(decl, ":")
+		if ok {
+			decls[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return decls
+}
+
+// svgGradients returns the color of the first stop of every gradient of an SVG document by id.
+func svgGradients(data []byte) map[string]Color {
+	type gradient struct {
+		href  string
+		stops []string
+	}
+	found := map[string]*gradient{}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	var current *gradient
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			attrs := svgAttrs(el)
+			switch el.Name.Local {
+			case "linearGradient", "radialGradient":
+				current = &gradient{href: strings.TrimPrefix(attrs["href"], "#")}
+				found[attrs["id"]] = current
+			case "stop":
+				if current == nil {
+					continue
+				}
+				stop := attrs["stop-color"]
+				if v, ok := svgDeclarations(attrs["style"])["stop-color"]; ok {
+					stop = v
+				}
+				current.stops = append(current.stops, stop)
+			}
+		case xml.EndElement:
+			if el.Name.Local == "linearGradient" || el.Name.Local == "radialGradient" {
+				current = nil
+			}
+		}
+	}
+
+	colors := map[string]Color{}
+	for id, g := range found {
+		// Gradients without stops use the stops of the gradient they reference
+		for i := 0; len(g.stops) == 0 && g.href != "" && found[g.href] != nil && i < 10; i++ {
+			g = found[g.href]
+		}
+		if len(g.stops) > 0 {
+			if c := parseSVGColor(g.stops[0], nil); c != nil {
+				colors[id] = *c
+			}
+		}
+	}
+	return colors
+}
+
+// svgNamedColors lists the color keywords used by logos.
+var svgNamedColors = map[string]Color{
+	"black": {0, 0, 0}, "white": {255, 255, 255}, "red": {255, 0, 0}, "green": {0, 128, 0},
+	"blue": {0, 0, 255}, "yellow": {255, 255, 0}, "orange": {255, 165, 0}, "gray": {128, 128, 128},
+	"grey": {128, 128, 128}, "silver": {192, 192, 192}, "navy": {0, 0, 128}, "purple": {128, 0, 128},
+	"currentcolor": {0, 0, 0},
+}
+
+var svgURL = regexp.MustCompile(`^url\(\s*['"]?#([^'")]+)['"]?\s*\)`)
+
+// parseSVGColor parses an SVG paint, returning nil for none, unknown paints and unknown gradients.
+func parseSVGColor(s string, gradients map[string]Color) *Color {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if m := svgURL.FindStringSubmatch(s); m != nil {
+		if c, ok := gradients[m[1]]; ok {
+			return &c
+		}
+		// A fallback paint may follow the URL
+		return parseSVGColor(strings.TrimSpace(s[len(m[0]):]), nil)
+	}
+	if c, ok := svgNamedColors[s]; ok {
+		return &c
+	}
+
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) == 3 || len(hex) == 4 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) == 8 {
+			hex = hex[:6]
+		}
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil && len(hex) == 6 {
+			return &Color{Red: int(v >> 16), Green: int(v >> 8 & 0xff), Blue: int(v & 0xff)}
+		}
+		return nil
+	}
+
+	if inner, ok := strings.CutPrefix(s, "rgb("); ok {
+		parts := strings.Split(strings.TrimSuffix(inner, ")"), ",")
+		if len(parts) != 3 {
+			return nil
+		}
+		var rgb [3]int
+		for i, p := range parts {
+			p = strings.TrimSpace(p)
+			pct := strings.HasSuffix(p, "%")
+			v, err := strconv.ParseFloat(strings.TrimSuffix(p, "%"), 64)
+			if err != nil {
+				return nil
+			}
+			if pct {
+				v = v * 255 / 100
+			}
+			rgb[i] = int(math.Round(math.Max(0, math.Min(255, v))))
+		}
+		return &Color{Red: rgb[0], Green: rgb[1], Blue: rgb[2]}
+	}
+	return nil
+}
+
+// svgLength parses a length in user units, ignoring a px unit.
+func svgLength(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "px"), 64)
+	return v, err == nil
+}
+
+// svgNumbers parses a list of numbers separated by spaces or commas.
+func svgNumbers(s string) []float64 {
+	var nums []float64
+	sc := svgScanner{s: s}
+	for {
+		v, ok := sc.number()
+		if !ok {
+			return nums
+		}
+		nums = append(nums, v)
+	}
+}
+
+var svgTransformFunc = regexp.MustCompile(`(\w+)\s*\(([^)]*)\)`)
+
+// parseSVGTransform parses a transform attribute.
+func parseSVGTransform(s string) svgMatrix {
+	m := svgIdentity
+	for _, f := range svgTransformFunc.FindAllStringSubmatch(s, -1) {
+		args := svgNumbers(f[2])
+		arg := func(i int, fallback float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return fallback
+		}
+
+		var t svgMatrix
+		switch f[1] {
+		case "matrix":
+			if len(args) != 6 {
+				continue
+			}
+			copy(t[:], args)
+		case "translate":
+			t = svgMatrix{1, 0, 0, 1, arg(0, 0), arg(1, 0)}
+		case "scale":
+			sx := arg(0, 1)
+			t = svgMatrix{sx, 0, 0, arg(1, sx), 0, 0}
+		case "rotate":
+			a := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			t = svgMatrix{1, 0, 0, 1, cx, cy}.
+				mul(svgMatrix{math.Cos(a), math.Sin(a), -math.Sin(a), math.Cos(a), 0, 0}).
+				mul(svgMatrix{1, 0, 0, 1, -cx, -cy})
+		case "skewX":
+			t = svgMatrix{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = svgMatrix{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			continue
+		}
+		m = m.mul(t)
+	}
+	return m
+}
+
+// svgElementPath returns the outline of a path or basic shape element, or nil for other elements.
+func svgElementPath(name string, attrs map[string]string) []svgSegment {
+	num := func(key string) float64 {
+		v, _ := svgLength(attrs[key])
+		return v
+	}
+
+	var b svgPathBuilder
+	switch name {
+	case "path":
+		return parseSVGPath(attrs["d"])
+	case "rect":
+		x, y, w, h := num("x"), num("y"), num("width"), num("height")
+		if w <= 0 || h <= 0 {
+			return nil
+		}
+		rx, hasRX := svgLength(attrs["rx"])
+		ry, hasRY := svgLength(attrs["ry"])
+		if !hasRX {
+			rx = ry
+		}
+		if !hasRY {
+			ry = rx
+		}
+		rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+		if rx <= 0 || ry <= 0 {
+			b.moveTo(svgPoint{x, y})
+			b.lineTo(svgPoint{x + w, y})
+			b.lineTo(svgPoint{x + w, y + h})
+			b.lineTo(svgPoint{x, y + h})
+			b.close()
+			return b.path
+		}
+		b.moveTo(svgPoint{x + rx, y})
+		b.lineTo(svgPoint{x + w - rx, y})
+		b.arcTo(rx, ry, 0, false, true, svgPoint{x + w, y + ry})
+		b.lineTo(svgPoint{x + w, y + h - ry})
+		b.arcTo(rx, ry, 0, false, true, svgPoint{x + w - rx, y + h})
+		b.lineTo(svgPoint{x + rx, y + h})
+		b.arcTo(rx, ry, 0, false, true, svgPoint{x, y + h - ry})
+		b.lineTo(svgPoint{x, y + ry})
+		b.arcTo(rx, ry, 0, false, true, svgPoint{x + rx, y})
+		b.close()
+		return b.path
+	case "circle", "ellipse":
+		cx, cy := num("cx"), num("cy")
+		rx, ry := num("rx"), num("ry")
+		if name == "circle" {
+			rx, ry = num("r"), num("r")
+		}
+		if rx <= 0 || ry <= 0 {
+			return nil
+		}
+		b.moveTo(svgPoint{cx + rx, cy})
+		b.arcTo(rx, ry, 0, false, true, svgPoint{cx - rx, cy})
+		b.arcTo(rx, ry, 0, false, true, svgPoint{cx + rx, cy})
+		b.close()
+		return b.path
+	case "line":
+		b.moveTo(svgPoint{num("x1"), num("y1")})
+		b.lineTo(svgPoint{num("x2"), num("y2")})
+		return b.path
+	case "polygon", "polyline":
+		nums := svgNumbers(attrs["points"])
+		for i := 0; i+1 < len(nums); i += 2 {
+			if i == 0 {
+				b.moveTo(svgPoint{nums[i], nums[i+1]})
+			} else {
+				b.lineTo(svgPoint{nums[i], nums[i+1]})
+			}
+		}
+		if name == "polygon" && len(b.path) > 0 {
+			b.close()
+		}
+		return b.path
+	}
+	return nil
+}
+
+// svgPathBuilder builds a path from absolute commands, converting curves and arcs to cubic Béziers.
+type svgPathBuilder struct {
+	path         []svgSegment
+	current      svgPoint
+	start        svgPoint
+	lastCommand  byte
+	lastControl  svgPoint // Second control point of the last cubic curve, reflected by S
+	lastQuadCtrl svgPoint // Control point of the last quadratic curve, reflected by T
+}
+
+func (b *svgPathBuilder) moveTo(p svgPoint) {
+	b.path = append(b.path, svgSegment{op: 'M', pts: [3]svgPoint{p}})
+	b.current, b.start = p, p
+}
+
+func (b *svgPathBuilder) lineTo(p svgPoint) {
+	b.path = append(b.path, svgSegment{op: 'L', pts: [3]svgPoint{p}})
+	b.current = p
+}
+
+func (b *svgPathBuilder) cubicTo(c1, c2, p svgPoint) {
+	b.path = append(b.path, svgSegment{op: 'C', pts: [3]svgPoint{c1, c2, p}})
+	b.current, b.lastControl = p, c2
+}
+
+func (b *svgPathBuilder) quadTo(c, p svgPoint) {
+	p0 := b.current
+	c1 := svgPoint{p0.x + 2.0/3*(c.x-p0.x), p0.y + 2.0/3*(c.y-p0.y)}
+	c2 := svgPoint{p.x + 2.0/3*(c.x-p.x), p.y + 2.0/3*(c.y-p.y)}
+	b.cubicTo(c1, c2, p)
+	b.lastQuadCtrl = c
+}
+
+func (b *svgPathBuilder) close() {
+	b.path = append(b.path, svgSegment{op: 'Z'})
+	b.current = b.start
+}
+
+// arcTo converts an elliptical arc to cubic Béziers, following the endpoint to center
+// conversion of the SVG specification.
+func (b *svgPathBuilder) arcTo(rx, ry, rotation float64, large, sweep bool, p svgPoint) {
+	p0 := b.current
+	if p0 == p {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		b.lineTo(p)
+		return
+	}
+
+	phi := rotation * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+	dx, dy := (p0.x-p.x)/2, (p0.y-p.y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	// Scale up radii that are too small to reach the end point
+	if l := x1*x1/(rx*rx) + y1*y1/(ry*ry); l > 1 {
+		rx, ry = rx*math.Sqrt(l), ry*math.Sqrt(l)
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	cx := cos*cx1 - sin*cy1 + (p0.x+p.x)/2
+	cy := sin*cx1 + cos*cy1 + (p0.y+p.y)/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	// Split the arc in segments of at most 90 degrees
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+	point := func(t float64) (svgPoint, svgPoint) {
+		ex, ey := rx*math.Cos(t), ry*math.Sin(t)
+		tx, ty := -rx*math.Sin(t), ry*math.Cos(t)
+		return svgPoint{cos*ex - sin*ey + cx, sin*ex + cos*ey + cy}, svgPoint{cos*tx - sin*ty, sin*tx + cos*ty}
+	}
+	for i := 0; i < n; i++ {
+		t0, t1 := theta+float64(i)*step, theta+float64(i+1)*step
+		a, da := point(t0)
+		e, de := point(t1)
+		if i == n-1 {
+			e = p
+		}
+		b.cubicTo(svgPoint{a.x + k*da.x, a.y + k*da.y}, svgPoint{e.x - k*de.x, e.y - k*de.y}, e)
+	}
+}
+
+// svgScanner reads the numbers and flags of path data and number lists.
+type svgScanner struct {
+	s string
+	i int
+}
+
+func (sc *svgScanner) skip() {
+	for sc.i < len(sc.s) && (sc.s[sc.i] == ' ' || sc.s[sc.i] == ',' || sc.s[sc.i] == '\t' || sc.s[sc.i] == '\n' || sc.s[sc.i] == '\r') {
+		sc.i++
+	}
+}
+
+// number reads the next number. Numbers can follow each other without separators, as in "1.5.5" or "1-2".
+func (sc *svgScanner) number() (float64, bool) {
+	sc.skip()
+	start := sc.i
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+	}
+	digits, dot := false, false
+scan:
+	for sc.i < len(sc.s) {
+		c := sc.s[sc.i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = true
+		case c == '.' && !dot:
+			dot = true
+		case (c == 'e' || c == 'E') && digits:
+			if sc.i+1 < len(sc.s) && (sc.s[sc.i+1] == '-' || sc.s[sc.i+1] == '+') {
+				sc.i++
+			}
+		default:
+			break scan
+		}
+		sc.i++
+	}
+	if !digits {
+		sc.i = start
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(sc.s[start:sc.i], 64)
+	if err != nil {
+		sc.i = start
+		return 0, false
+	}
+	return v, true
+}
+
+// flag reads an arc flag, which can be written without a separator before the next number.
+func (sc *svgScanner) flag() (bool, bool) {
+	sc.skip()
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return sc.s[sc.i-1] == '1', true
+	}
+	return false, false
+}
+
+// parseSVGPath parses path data into absolute segments. Parsing stops at the first error,
+// keeping the segments before it, as SVG renderers do.
+func parseSVGPath(d string) []svgSegment {
+	var b svgPathBuilder
+	sc := svgScanner{s: d}
+	var cmd byte
+
+	for {
+		sc.skip()
+		if sc.i >= len(sc.s) {
+			break
+		}
+		if c := sc.s[sc.i]; (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			cmd = c
+			sc.i++
+		} else if cmd == 0 {
+			break
+		}
+
+		rel := cmd >= 'a'
+		abs := func(x, y float64) svgPoint {
+			if rel {
+				return svgPoint{b.current.x + x, b.current.y + y}
+			}
+			return svgPoint{x, y}
+		}
+		nums := func(n int) ([]float64, bool) {
+			vals := make([]float64, n)
+			for i := range vals {
+				v, ok := sc.number()
+				if !ok {
+					return nil, false
+				}
+				vals[i] = v
+			}
+			return vals, true
+		}
+
+		upper := cmd &^ 0x20
+		ok := true
+		switch upper {
+		case 'Z':
+			b.close()
+			cmd = 0 // A number after Z is an error
+			b.lastCommand = 'Z'
+			continue
+		case 'M':
+			var v []float64
+			if v, ok = nums(2); ok {
+				b.moveTo(abs(v[0], v[1]))
+				// Further coordinate pairs are implicit line commands
+				if rel {
+					cmd = 'l'
+				} else {
+					cmd = 'L'
+				}
+			}
+		case 'L':
+			var v []float64
+			if v, ok = nums(2); ok {
+				b.lineTo(abs(v[0], v[1]))
+			}
+		case 'H':
+			var v []float64
+			if v, ok = nums(1); ok {
+				x := v[0]
+				if rel {
+					x += b.current.x
+				}
+				b.lineTo(svgPoint{x, b.current.y})
+			}
+		case 'V':
+			var v []float64
+			if v, ok = nums(1); ok {
+				y := v[0]
+				if rel {
+					y += b.current.y
+				}
+				b.lineTo(svgPoint{b.current.x, y})
+			}
+		case 'C':
+			var v []float64
+			if v, ok = nums(6); ok {
+				b.cubicTo(abs(v[0], v[1]), abs(v[2], v[3]), abs(v[4], v[5]))
+			}
+		case 'S':
+			var v []float64
+			if v, ok = nums(4); ok {
+				c1 := b.current
+				if b.lastCommand == 'C' || b.lastCommand == 'S' {
+					c1 = svgPoint{2*b.current.x - b.lastControl.x, 2*b.current.y - b.lastControl.y}
+				}
+				b.cubicTo(c1, abs(v[0], v[1]), abs(v[2], v[3]))
+			}
+		case 'Q':
+			var v []float64
+			if v, ok = nums(4); ok {
+				b.quadTo(abs(v[0], v[1]), abs(v[2], v[3]))
+			}
+		case 'T':
+			var v []float64
+			if v, ok = nums(2); ok {
+				c := b.current
+				if b.lastCommand == 'Q' || b.lastCommand == 'T' {
+					c = svgPoint{2*b.current.x - b.lastQuadCtrl.x, 2*b.current.y - b.lastQuadCtrl.y}
+				}
+				b.quadTo(c, abs(v[0], v[1]))
+			}
+		case 'A':
+			var v []float64
+			var large, sweep, okL, okS bool
+			if v, ok = nums(3); ok {
+				large, okL = sc.flag()
+				sweep, okS = sc.flag()
+				var end []float64
+				if end, ok = nums(2); ok && okL && okS {
+					b.arcTo(v[0], v[1], v[2], large, sweep, abs(end[0], end[1]))
+				} else {
+					ok = false
+				}
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			break
+		}
+		b.lastCommand = upper
+	}
+
+	return b.path
+}
+
+// heightFor returns the height of an SVG image drawn width wide, keeping its aspect ratio.
+func (img *svgImage) heightFor(width float64) float64 {
+	return width * img.height / img.width
+}
+
+// renderSVG draws an SVG image as vector paths at (x, y), width wide.
+func (pg *PDFGenerator) renderSVG(pdf *gofpdf.Fpdf, img *svgImage, x, y, width float64) {
+	scale := width / img.width
+	tr := func(p svgPoint) (float64, float64) {
+		return x + (p.x-img.minX)*scale, y + (p.y-img.minY)*scale
+	}
+
+	for _, shape := range img.shapes {
+		for _, seg := range shape.path {
+			switch seg.op {
+			case 'M':
+				pdf.MoveTo(tr(seg.pts[0]))
+			case 'L':
+				pdf.LineTo(tr(seg.pts[0]))
+			case 'C':
+				c1x, c1y := tr(seg.pts[0])
+				c2x, c2y := tr(seg.pts[1])
+				px, py := tr(seg.pts[2])
+				pdf.CurveBezierCubicTo(c1x, c1y, c2x, c2y, px, py)
+			case 'Z':
+				pdf.ClosePath()
+			}
+		}
+
+		op := ""
+		if shape.fill != nil {
+			setFillColor(pdf, shape.fill)
+			op = "F"
+		}
+		if shape.stroke != nil {
+			pdf.SetDrawColor(shape.stroke.Red, shape.stroke.Green, shape.stroke.Blue)
+			pdf.SetLineWidth(shape.strokeWidth * scale)
+			op += "D"
+		}
+		if shape.evenOdd && shape.fill != nil {
+			op += "*"
+		}
+		if shape.opacity < 1 {
+			pdf.SetAlpha(shape.opacity, "Normal")
+		}
+		pdf.DrawPath(op)
+		if shape.opacity < 1 {
+			pdf.SetAlpha(1, "Normal")
+		}
+	}
+}
+
+// rasterizeSVG renders an SVG image, width millimeters wide, into a PNG at the given resolution.
+// Strokes are outlined into polygons with the miter joins and butt caps SVG draws by default.
+func rasterizeSVG(img *svgImage, width float64, dpi int) ([]byte, error) {
+	w := max(1, int(math.Round(width/25.4*float64(dpi))))
+	h := max(1, int(math.Round(img.heightFor(width)/25.4*float64(dpi))))
+	scale := float64(w) / img.width
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	z := vector.NewRasterizer(w, h)
+	tr := func(p svgPoint) svgPoint {
+		return svgPoint{(p.x - img.minX) * scale, (p.y - img.minY) * scale}
+	}
+
+	for _, shape := range img.shapes {
+		lines := flattenSVGPath(shape.path, tr)
+		if shape.fill != nil {
+			src := svgPaint(shape.fill, shape.opacity)
+			if shape.evenOdd {
+				draw.DrawMask(dst, dst.Bounds(), src, image.Point{}, evenOddMask(lines, w, h), image.Point{}, draw.Over)
+			} else {
+				// The rasterizer fills with the non-zero winding rule
+				z.Reset(w, h)
+				for _, l := range lines {
+					addPolygon(z, l.pts)
+				}
+				z.Draw(dst, dst.Bounds(), src, image.Point{})
+			}
+		}
+		if shape.stroke != nil && shape.strokeWidth > 0 {
+			// The polygons of a stroke overlap, so they all wind the same way to add up
+			z.Reset(w, h)
+			for _, polygon := range strokeOutline(lines, shape.strokeWidth*scale) {
+				addPolygon(z, orient(polygon))
+			}
+			z.Draw(dst, dst.Bounds(), svgPaint(shape.stroke, shape.opacity), image.Point{})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// svgPaint returns the colour of a fill or stroke at an opacity.
+func svgPaint(c *Color, opacity float64) image.Image {
+	return image.NewUniform(color.NRGBA{
+		R: uint8(c.Red), G: uint8(c.Green), B: uint8(c.Blue),
+		A: uint8(math.Round(255 * math.Max(0, math.Min(1, opacity)))),
+	})
+}
+
+// svgPolyline is a subpath of an SVG path with its curves flattened into lines.
+type svgPolyline struct {
+	pts    []svgPoint
+	closed bool
+}
+
+// svgCurveStep is the length, in pixels, of the control polygon of a curve per line it is
+// flattened into.
+const svgCurveStep = 2
+
+// flattenSVGPath transforms the points of a path with tr and flattens its curves into lines,
+// returning a polyline for each subpath.
+func flattenSVGPath(path []svgSegment, tr func(svgPoint) svgPoint) []svgPolyline {
+	var lines []svgPolyline
+	// current returns the polyline segments are added to. Drawing after Z starts a new subpath
+	// at the start of the closed one.
+	current := func() *svgPolyline {
+		if l := lines[len(lines)-1]; l.closed {
+			lines = append(lines, svgPolyline{pts: []svgPoint{l.pts[0]}})
+		}
+		return &lines[len(lines)-1]
+	}
+
+	for _, seg := range path {
+		if seg.op != 'M' && len(lines) == 0 {
+			continue
+		}
+		switch seg.op {
+		case 'M':
+			lines = append(lines, svgPolyline{pts: []svgPoint{tr(seg.pts[0])}})
+		case 'L':
+			l := current()
+			l.pts = append(l.pts, tr(seg.pts[0]))
+		case 'C':
+			l := current()
+			p0 := l.pts[len(l.pts)-1]
+			c1, c2, p := tr(seg.pts[0]), tr(seg.pts[1]), tr(seg.pts[2])
+			length := svgDistance(p0, c1) + svgDistance(c1, c2) + svgDistance(c2, p)
+			n := min(1024, max(1, int(math.Ceil(length/svgCurveStep))))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				u := 1 - t
+				l.pts = append(l.pts, svgPoint{
+					u*u*u*p0.x + 3*u*u*t*c1.x + 3*u*t*t*c2.x + t*t*t*p.x,
+					u*u*u*p0.y + 3*u*u*t*c1.y + 3*u*t*t*c2.y + t*t*t*p.y,
+				})
+			}
+		case 'Z':
+			lines[len(lines)-1].closed = true
+		}
+	}
+	return lines
+}
+
+// svgMiterLimit is the SVG default limit of the length of a miter join, relative to the stroke
+// width, beyond which the join is bevelled.
+const svgMiterLimit = 4
+
+// strokeOutline returns the polygons that cover the stroke of polylines, width wide: a rectangle
+// along each line and a miter or bevel join where two lines meet. Open polylines end flat.
+func strokeOutline(lines []svgPolyline, width float64) [][]svgPoint {
+	hw := width / 2
+	var polygons [][]svgPoint
+	for _, l := range lines {
+		var pts []svgPoint
+		for _, p := range l.pts {
+			if len(pts) == 0 || p != pts[len(pts)-1] {
+				pts = append(pts, p)
+			}
+		}
+		if l.closed && len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+			pts = pts[:len(pts)-1]
+		}
+		if len(pts) < 2 {
+			continue
+		}
+
+		segments := len(pts) - 1
+		if l.closed {
+			segments = len(pts)
+		}
+		for i := range segments {
+			a, b := pts[i], pts[(i+1)%len(pts)]
+			n := svgNormal(a, b)
+			n.x, n.y = n.x*hw, n.y*hw
+			polygons = append(polygons, []svgPoint{
+				{a.x + n.x, a.y + n.y}, {b.x + n.x, b.y + n.y}, {b.x - n.x, b.y - n.y}, {a.x - n.x, a.y - n.y},
+			})
+		}
+		for i := range pts {
+			if !l.closed && (i == 0 || i == len(pts)-1) {
+				continue
+			}
+			prev, next := pts[(i+len(pts)-1)%len(pts)], pts[(i+1)%len(pts)]
+			if join := svgJoin(prev, pts[i], next, hw); join != nil {
+				polygons = append(polygons, join)
+			}
+		}
+	}
+	return polygons
+}
+
+// svgJoin returns the miter join, or the bevel join beyond the miter limit, on the outer side of
+// the corner at p between the lines from prev and to next, or nil if the lines are straight.
+func svgJoin(prev, p, next svgPoint, hw float64) []svgPoint {
+	n1, n2 := svgNormal(prev, p), svgNormal(p, next)
+	d2 := svgPoint{n2.y, -n2.x}  // Direction of the line to next
+	cos := n1.x*n2.x + n1.y*n2.y // Cosine of the turn between the lines
+	if cos > 1-1e-9 {
+		return nil
+	}
+	// The outer side is the one the corner turns away from
+	if n1.x*d2.x+n1.y*d2.y > 0 {
+		n1, n2 = svgPoint{-n1.x, -n1.y}, svgPoint{-n2.x, -n2.y}
+	}
+	a := svgPoint{p.x + n1.x*hw, p.y + n1.y*hw}
+	b := svgPoint{p.x + n2.x*hw, p.y + n2.y*hw}
+
+	// The miter reaches 1/cos(turn/2) half widths from the corner
+	cosHalf := math.Sqrt((1 + cos) / 2)
+	if cosHalf*svgMiterLimit < 1 {
+		return []svgPoint{p, a, b}
+	}
+	bisector := svgPoint{n1.x + n2.x, n1.y + n2.y}
+	length := hw / cosHalf / math.Hypot(bisector.x, bisector.y)
+	return []svgPoint{p, a, {p.x + bisector.x*length, p.y + bisector.y*length}, b}
+}
+
+// svgNormal returns the unit normal of the line from a to b.
+func svgNormal(a, b svgPoint) svgPoint {
+	d := svgDistance(a, b)
+	return svgPoint{-(b.y - a.y) / d, (b.x - a.x) / d}
+}
+
+func svgDistance(a, b svgPoint) float64 {
+	return math.Hypot(b.x-a.x, b.y-a.y)
+}
+
+// orient returns a polygon with its points in the order that gives it a positive area.
+func orient(polygon []svgPoint) []svgPoint {
+	var area float64
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		area += a.x*b.y - b.x*a.y
+	}
+	if area < 0 {
+		polygon = slices.Clone(polygon)
+		slices.Reverse(polygon)
+	}
+	return polygon
+}
+
+// addPolygon adds the closed polygon through pts to a rasterizer.
+func addPolygon(z *vector.Rasterizer, pts []svgPoint) {
+	for i, p := range pts {
+		if i == 0 {
+			z.MoveTo(float32(p.x), float32(p.y))
+		} else {
+			z.LineTo(float32(p.x), float32(p.y))
+		}
+	}
+	z.ClosePath()
+}
+
+// svgSubsamples is the number of heights each row of pixels is sampled at by evenOddMask.
+const svgSubsamples = 4
+
+// evenOddMask returns the coverage of polylines, filled as closed polygons with the even-odd
+// rule, which vector.Rasterizer does not support, in a w by h mask. Each row of pixels is sampled
+// at svgSubsamples heights, adding the exact part of each pixel covered at that height.
+func evenOddMask(lines []svgPolyline, w, h int) *image.Alpha {
+	mask := image.NewAlpha(image.Rect(0, 0, w, h))
+	cover := make([]float64, w)
+	var xs []float64
+	for y := range h {
+		clear(cover)
+		for k := range svgSubsamples {
+			sy := float64(y) + (float64(k)+0.5)/svgSubsamples
+			xs = xs[:0]
+			for _, l := range lines {
+				for i, a := range l.pts {
+					b := l.pts[(i+1)%len(l.pts)]
+					if (a.y <= sy) != (b.y <= sy) {
+						xs = append(xs, a.x+(sy-a.y)*(b.x-a.x)/(b.y-a.y))
+					}
+				}
+			}
+			slices.Sort(xs)
+			for i := 0; i+1 < len(xs); i += 2 {
+				x0, x1 := max(xs[i], 0), min(xs[i+1], float64(w))
+				for x0 < x1 {
+					end := min(x1, math.Floor(x0)+1)
+					cover[int(x0)] += end - x0
+					x0 = end
+				}
+			}
+		}
+		for x, c := range cover {
+			mask.Pix[y*mask.Stride+x] = uint8(math.Round(255 * min(1, c/svgSubsamples)))
+		}
+	}
+	return mask
+}
+
+// svg returns the parsed SVG image at path, parsing it on first use.
+func (pg *PDFGenerator) svg(path string) (*svgImage, error) {
+	if img, ok := pg.svgs[path]; ok {
+		return img, nil
+	}
+	img, err := loadSVG(path)
+	if err != nil {
+		return nil, err
+	}
+	if pg.svgs == nil {
+		pg.svgs = map[string]*svgImage{}
+	}
+	pg.svgs[path] = img
+	return img, nil
+}
+
+// isSVG reports whether an image path is an SVG file.
+func isSVG(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".svg")
+}
@@ -0,0 +1,263 @@
+package generator
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSVGPath(t *testing.T) {
+	pt := func(x, y float64) [3]svgPoint { return [3]svgPoint{{x, y}} }
+
+	t.Run("Relative commands", func(t *testing.T) {
+		path := parseSVGPath("M10 20l5-5h5v10zm1 1")
+		assert.Equal(t, []svgSegment{
+			{op: 'M', pts: pt(10, 20)},
+			{op: 'L', pts: pt(15, 15)},
+			{op: 'L', pts: pt(20, 15)},
+			{op: 'L', pts: pt(20, 25)},
+			{op: 'Z'},
+			{op: 'M', pts: pt(11, 21)},
+		}, path)
+	})
+
+	t.Run("Packed numbers and implicit line to", func(t *testing.T) {
+		path := parseSVGPath("M.5.5 1.5-1e1,2 3")
+		require.Len(t, path, 3)
+		assert.Equal(t, pt(0.5, 0.5), path[0].pts)
+		assert.Equal(t, svgSegment{op: 'L', pts: pt(1.5, -10)}, path[1])
+		assert.Equal(t, svgSegment{op: 'L', pts: pt(2, 3)}, path[2])
+	})
+
+	t.Run("Arcs become cubic curves", func(t *testing.T) {
+		path := parseSVGPath("M0 0a10 10 0 1110 10")
+		require.Greater(t, len(path), 1)
+		for _, seg := range path[1:] {
+			assert.Equal(t, byte('C'), seg.op)
+		}
+		end := path[len(path)-1].pts[2]
+		assert.InDelta(t, 10, end.x, 1e-9)
+		assert.InDelta(t, 10, end.y, 1e-9)
+	})
+
+	t.Run("Quadratic curves become cubic curves", func(t *testing.T) {
+		path := parseSVGPath("M0 0Q3 3 6 0t6 0")
+		require.Len(t, path, 3)
+		assert.Equal(t, svgPoint{2, 2}, path[1].pts[0])
+		assert.Equal(t, svgPoint{6, 0}, path[1].pts[2])
+		assert.Equal(t, svgPoint{12, 0}, path[2].pts[2])
+	})
+}
+
+func TestParseSVGColor(t *testing.T) {
+	gradients := map[string]Color{"brand": {Red: 1, Green: 2, Blue: 3}}
+
+	tests := []struct {
+		paint string
+		want  *Color
+	}{
+		{paint: "#E94147", want: &Color{Red: 233, Green: 65, Blue: 71}},
+		{paint: "#fff", want: &Color{Red: 255, Green: 255, Blue: 255}},
+		{paint: "rgb(10, 20, 30)", want: &Color{Red: 10, Green: 20, Blue: 30}},
+		{paint: "rgb(100%, 0%, 50%)", want: &Color{Red: 255, Green: 0, Blue: 128}},
+		{paint: "Black", want: &Color{}},
+		{paint: "url(#brand)", want: &Color{Red: 1, Green: 2, Blue: 3}},
+		{paint: "url('#missing') #000", want: &Color{}},
+		{paint: "url(#missing)"},
+		{paint: "none"},
+		{paint: "#12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.paint, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseSVGColor(tt.paint, gradients))
+		})
+	}
+}
+
+func TestParseSVGTransform(t *testing.T) {
+	apply := func(transform string, x, y float64) svgPoint {
+		return parseSVGTransform(transform).apply(svgPoint{x, y})
+	}
+
+	assert.Equal(t, svgPoint{11, 22}, apply("translate(10 20)", 1, 2))
+	assert.Equal(t, svgPoint{2, 6}, apply("scale(2, 3)", 1, 2))
+	assert.Equal(t, svgPoint{12, 24}, apply("translate(10,20) scale(2)", 1, 2))
+	assert.Equal(t, svgPoint{7, 10}, apply("matrix(1 0 0 1 5 6) translate(1 2)", 1, 2))
+
+	p := apply("rotate(90 10 10)", 20, 10)
+	assert.InDelta(t, 10, p.x, 1e-9)
+	assert.InDelta(t, 20, p.y, 1e-9)
+}
+
+func TestParseSVG(t *testing.T) {
+	t.Run("Styles, classes and gradients", func(t *testing.T) {
+		img, err := parseSVG([]byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="10 20 100 50">
+			<style>.logo { fill: #ff0000 } #dot { fill: blue; opacity: .5 }</style>
+			<defs>
+				<linearGradient id="base"><stop offset="0" stop-color="#00ff00"/></linearGradient>
+				<linearGradient id="fade" href="#base"/>
+			</defs>
+			<g fill="#000" transform="translate(10 20)">
+				<rect class="logo" width="10" height="10"/>
+				<circle id="dot" cx="5" cy="5" r="5"/>
+				<path d="M0 0h10v10z" fill="url(#fade)" stroke="#000" stroke-width="2"/>
+				<rect width="10" height="10" style="display:none"/>
+				<rect width="10" height="10"/>
+			</g>
+		</svg>`))
+		require.NoError(t, err)
+
+		assert.Equal(t, 10.0, img.minX)
+		assert.Equal(t, 20.0, img.minY)
+		assert.Equal(t, 25.0, img.heightFor(50))
+		require.Len(t, img.shapes, 4)
+
+		assert.Equal(t, &Color{Red: 255}, img.shapes[0].fill)
+		assert.Equal(t, svgPoint{10, 20}, img.shapes[0].path[0].pts[0])
+		assert.Equal(t, &Color{Blue: 255}, img.shapes[1].fill)
+		assert.Equal(t, 0.5, img.shapes[1].opacity)
+		assert.Equal(t, &Color{Green: 255}, img.shapes[2].fill)
+		assert.Equal(t, &Color{}, img.shapes[2].stroke)
+		assert.Equal(t, 2.0, img.shapes[2].strokeWidth)
+		assert.Equal(t, &Color{}, img.shapes[3].fill)
+	})
+
+	t.Run("Size from width and height", func(t *testing.T) {
+		img, err := parseSVG([]byte(`<svg width="40px" height="20"><rect width="1" height="1"/></svg>`))
+		require.NoError(t, err)
+		assert.Equal(t, 40.0, img.width)
+		assert.Equal(t, 20.0, img.height)
+	})
+
+	t.Run("Not an SVG file", func(t *testing.T) {
+		_, err := parseSVG([]byte(`<html></html>`))
+		assert.Error(t, err)
+	})
+}
+
+func TestPDFGenerator_RenderSVGImage(t *testing.T) {
+	pg, err := NewPDFGenerator("output", "templates", "default")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "logo.svg")
+	require.NoError(t, os.WriteFile(path, []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 20 10">
+		<rect width="20" height="10" rx="2" fill="#336699"/>
+	</svg>`), 0o644))
+
+	t.Run("Max height keeps the aspect ratio", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		w, h := pg.imageSize(pdf, 40, &ImageProp{Path: path})
+		assert.Equal(t, 40.0, w)
+		assert.Equal(t, 20.0, h)
+
+		w, h = pg.imageSize(pdf, 40, &ImageProp{Path: path, MaxHeight: 5})
+		assert.Equal(t, 10.0, w)
+		assert.Equal(t, 5.0, h)
+	})
+
+	t.Run("Vector paths", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pdf.SetCompression(false)
+		pg.renderRow(pdf, pg.page.content(), &Row{Cols: []Col{{Width: 4, Image: &ImageProp{Path: path, MaxHeight: 8, Vector: true}}}})
+		assert.InDelta(t, pg.page.top(1)+8, pdf.GetY(), 0.01)
+
+		var out bytes.Buffer
+		require.NoError(t, pdf.Output(&out))
+		assert.Contains(t, out.String(), "0.200 0.400 0.600 rg")
+		assert.NotContains(t, out.String(), "/Subtype /Image")
+	})
+
+	t.Run("Rasterised by default", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pg.renderRow(pdf, pg.page.content(), &Row{Cols: []Col{{Width: 4, Image: &ImageProp{Path: path, MaxHeight: 8}}}})
+		require.NotNil(t, pdf.GetImageInfo(path+"@16.00mm@300dpi"))
+	})
+
+	t.Run("Rasterised at a resolution", func(t *testing.T) {
+		pdf := newTestPDF(t, pg)
+		pg.renderRow(pdf, pg.page.content(), &Row{Cols: []Col{{Width: 4, Image: &ImageProp{Path: path, MaxHeight: 8, DPI: 150}}}})
+		assert.InDelta(t, pg.page.top(1)+8, pdf.GetY(), 0.01)
+		require.NotNil(t, pdf.GetImageInfo(path+"@16.00mm@150dpi"))
+
+		var out bytes.Buffer
+		require.NoError(t, pdf.Output(&out))
+		assert.Contains(t, out.String(), "/Subtype /Image")
+	})
+}
+
+func TestRasterizeSVG(t *testing.T) {
+	// rasterize renders an SVG of a 100 by 100 view box 100 pixels wide and returns the colour of a pixel
+	rasterize := func(t *testing.T, shapes string) func(x, y int) color.NRGBA {
+		img, err := parseSVG([]byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 100">` + shapes + `</svg>`))
+		require.NoError(t, err)
+		data, err := rasterizeSVG(img, 10, 254)
+		require.NoError(t, err)
+		decoded, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, 100, 100), decoded.Bounds())
+		return func(x, y int) color.NRGBA {
+			return color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+		}
+	}
+	red := color.NRGBA{R: 255, A: 255}
+	black := color.NRGBA{A: 255}
+
+	t.Run("Strokes", func(t *testing.T) {
+		at := rasterize(t, `<path d="M 20 20 H 80 V 80 H 20 Z" fill="none" stroke="#ff0000" stroke-width="10"/>`)
+		assert.Equal(t, red, at(50, 20))
+		assert.Equal(t, red, at(80, 50))
+		assert.Equal(t, red, at(16, 16), "corners have miter joins")
+		assert.Zero(t, at(50, 50).A)
+		assert.Zero(t, at(10, 10).A)
+	})
+
+	t.Run("Open strokes end flat", func(t *testing.T) {
+		at := rasterize(t, `<polyline points="10,50 90,50" stroke="#ff0000" stroke-width="4"/>`)
+		assert.Equal(t, red, at(50, 49))
+		assert.Equal(t, red, at(10, 50))
+		assert.Zero(t, at(8, 50).A)
+		assert.Zero(t, at(50, 45).A)
+	})
+
+	t.Run("Sharp corners are bevelled", func(t *testing.T) {
+		at := rasterize(t, `<path d="M 10 60 L 50 50 L 10 40" fill="none" stroke="#ff0000" stroke-width="10"/>`)
+		assert.Equal(t, red, at(40, 50))
+		assert.Zero(t, at(60, 50).A, "the miter is beyond the miter limit")
+	})
+
+	t.Run("Fill and stroke", func(t *testing.T) {
+		at := rasterize(t, `<rect x="20" y="20" width="60" height="60" stroke="#ff0000" stroke-width="10"/>`)
+		assert.Equal(t, black, at(50, 50))
+		assert.Equal(t, red, at(50, 22), "strokes are drawn over fills")
+	})
+
+	squares := `d="M 10 10 H 90 V 90 H 10 Z M 30 30 H 70 V 70 H 30 Z"`
+
+	t.Run("Non-zero fills", func(t *testing.T) {
+		at := rasterize(t, `<path `+squares+`/>`)
+		assert.Equal(t, black, at(20, 20))
+		assert.Equal(t, black, at(50, 50))
+		assert.Zero(t, at(5, 5).A)
+	})
+
+	t.Run("Even-odd fills", func(t *testing.T) {
+		at := rasterize(t, `<path `+squares+` fill-rule="evenodd"/>`)
+		assert.Equal(t, black, at(20, 20))
+		assert.Zero(t, at(50, 50).A)
+		assert.Zero(t, at(5, 5).A)
+	})
+
+	t.Run("Even-odd fills are anti-aliased", func(t *testing.T) {
+		at := rasterize(t, `<path d="M 10.5 10 H 90 V 90 H 10.5 Z" fill-rule="evenodd" fill-opacity="0.5"/>`)
+		assert.InDelta(t, 64, int(at(10, 50).A), 2)
+		assert.InDelta(t, 128, int(at(50, 50).A), 1)
+	})
+}
@@ -6,67 +6,270 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Template represents the YAML structure of a resume template.
-// It contains rows for the main content and an optional footer.
+// It contains the fonts and named text styles of the theme, rows for the main content, an optional
+// header repeated from the second page and an optional footer on every page.
 type Template struct {
-	Rows   []Row `yaml:"rows"`
-	Footer *Row  `yaml:"footer,omitempty"`
+	Page    Page                 `yaml:"page,omitempty"`
+	Fit     *Fit                 `yaml:"fit,omitempty"`
+	Fonts   map[string]FontProp  `yaml:"fonts,omitempty"`
+	Styles  map[string]TextStyle `yaml:"styles,omitempty"`
+	Sidebar *Sidebar             `yaml:"sidebar,omitempty"`
+	Header  *Row                 `yaml:"header,omitempty"`
+	Rows    []Row                `yaml:"rows"`
+	Footer  *Row                 `yaml:"footer,omitempty"`
+
+	// FallbackFonts are the font families tried, in order, for the characters the font of a text
+	// has no glyph for, such as CJK characters
+	FallbackFonts []string `yaml:"fallback_fonts,omitempty"`
+}
+
+// Page defines the size, orientation and margins of the pages. Settings that a template
+// does not declare keep their defaults: A4 portrait with 10 mm margins.
+type Page struct {
+	Size        string      `yaml:"size,omitempty"`        // A3, A4, A5, Letter, Legal, or <width>x<height> in mm
+	Orientation string      `yaml:"orientation,omitempty"` // portrait or landscape
+	Margins     Margins     `yaml:"margins,omitempty"`
+	Background  *Background `yaml:"background,omitempty"`
+}
+
+// Fit bounds how far the template shrinks to fit a maximum number of pages, as the smallest
+// fraction of their size that font sizes, line spacing and vertical spacing scale down to.
+type Fit struct {
+	FontScale    float64 `yaml:"font_scale,omitempty"`    // Font sizes, 0.8 by default
+	LineScale    float64 `yaml:"line_scale,omitempty"`    // Line heights as a multiple of the font size, 0.85 by default
+	SpacingScale float64 `yaml:"spacing_scale,omitempty"` // Row heights and space between list items, 0.5 by default
+	Steps        int     `yaml:"steps,omitempty"`         // Sizes tried down to the smallest scale, 8 by default
+}
+
+// Background fills every page with a color, an image stretched over the page, or both.
+type Background struct {
+	Color *Color `yaml:"color,omitempty"`
+	Image string `yaml:"image,omitempty"` // Path of a PNG, JPEG or GIF image
+}
+
+// Margins defines the page margins in millimeters. The header and footer are rendered between the
+// margins, taking their space from the content.
+type Margins struct {
+	Top    float64 `yaml:"top"`
+	Right  float64 `yaml:"right"`
+	Bottom float64 `yaml:"bottom"`
+	Left   float64 `yaml:"left"`
+}
+
+// Sidebar is a full-height region beside the main rows. Its rows flow independently of the main
+// rows and continue on the next page when they do not fit. The main rows use the rest of the page
+// width as their own 12-column grid.
+type Sidebar struct {
+	Width int     `yaml:"width"`          // Columns of the 12-column page grid
+	Side  string  `yaml:"side,omitempty"` // left (default) or right
+	Gap   float64 `yaml:"gap,omitempty"`  // Space between the sidebar and the main rows in millimeters
+	// Background shades the sidebar on every page, from the page edge to the middle of the gap
+	Background *Color `yaml:"background,omitempty"`
+	Rows       []Row  `yaml:"rows"`
+}
+
+// FontProp lists the TTF files of a font family per style, relative to the theme directory.
+// Styles that are not listed fall back to the closest listed style.
+type FontProp struct {
+	Regular    string `yaml:"regular,omitempty"`
+	Bold       string `yaml:"bold,omitempty"`
+	Italic     string `yaml:"italic,omitempty"`
+	BoldItalic string `yaml:"bolditalic,omitempty"`
+}
+
+// TextStyle defines a named set of text properties that text columns reference by name.
+// The style named "default" applies to every text column.
+type TextStyle struct {
+	Family  string  `yaml:"family,omitempty"`
+	Size    float64 `yaml:"size,omitempty"`
+	Weight  string  `yaml:"weight,omitempty"` // normal, bold, italic, bolditalic
+	Color   *Color  `yaml:"color,omitempty"`
+	Spacing float64 `yaml:"spacing,omitempty"` // Line height as a multiple of the font size
 }
 
-// Row represents a horizontal row in the PDF with a specified height and columns.
+// Row represents a horizontal row in the PDF with columns. The row is as tall as its tallest
+// column, and at least as tall as its height. A row with rows instead of columns groups them,
+// so the page break rules of the row apply to the whole group.
 type Row struct {
-	Height float64 `yaml:"height"`
+	Height float64 `yaml:"height"` // Minimum height in millimeters
 	Cols   []Col   `yaml:"cols"`
+	Rows   []Row   `yaml:"rows,omitempty"`
+	// KeepTogether moves the row to the next page instead of splitting it, unless it is taller than a page
+	KeepTogether bool `yaml:"keep_together,omitempty"`
+	// KeepWithNext keeps the row on the same page as the start of the next row
+	KeepWithNext bool   `yaml:"keep_with_next,omitempty"`
+	PageBreak    string `yaml:"page_break,omitempty"` // before or after the row
+	// Bookmark is the title of an entry of the document outline pointing at the row
+	Bookmark      string `yaml:"bookmark,omitempty"`
+	BookmarkLevel int    `yaml:"bookmark_level,omitempty"` // 0 for sections, 1 for their entries, and so on
 }
 
-// Col represents a column within a row, which can contain text, a line, or an image.
+// Col represents a column within a row, which can contain text, a list, a line, a shape, a rating,
+// a radar chart, an image, or nested rows forming a 12-column grid of the width of the column.
 type Col struct {
-	Width int        `yaml:"width"`
-	Text  *TextProp  `yaml:"text,omitempty"`
-	Line  *LineProp  `yaml:"line,omitempty"`
-	Image *ImageProp `yaml:"image,omitempty"`
+	Width      int         `yaml:"width"`
+	Background *Color      `yaml:"background,omitempty"` // Fills the column over the height of the row
+	Text       *TextProp   `yaml:"text,omitempty"`
+	List       *ListProp   `yaml:"list,omitempty"`
+	Line       *LineProp   `yaml:"line,omitempty"`
+	Shape      *ShapeProp  `yaml:"shape,omitempty"`
+	Rating     *RatingProp `yaml:"rating,omitempty"`
+	Radar      *RadarProp  `yaml:"radar,omitempty"`
+	Image      *ImageProp  `yaml:"image,omitempty"`
+	Rows       []Row       `yaml:"rows,omitempty"`
 }
 
 // TextProp defines properties for text content in a column.
 type TextProp struct {
-	Content   string `yaml:"content"`
-	Size      int    `yaml:"size"`
-	Style     string `yaml:"style"` // normal, bold, italic, bolditalic
-	Align     string `yaml:"align"` // left, center, right, justify
-	Color     *Color `yaml:"color,omitempty"`
-	Hyperlink string `yaml:"hyperlink,omitempty"`
+	Content   string  `yaml:"content"`
+	Size      float64 `yaml:"size"`
+	Style     string  `yaml:"style"` // named style, or normal, bold, italic, bolditalic
+	Align     string  `yaml:"align"` // left, center, right, justify
+	Color     *Color  `yaml:"color,omitempty"`
+	Hyperlink string  `yaml:"hyperlink,omitempty"`
+	Markdown  bool    `yaml:"markdown,omitempty"` // **bold**, *italic* and [links](url) within the content
 }
 
-// LineProp defines properties for a horizontal line in a column.
+// ListProp defines a bulleted or numbered list in a column. The items are either listed or
+// split from a text on its "- ", "* " or "1. " list markers.
+type ListProp struct {
+	Items       []string `yaml:"items,omitempty"`
+	Content     string   `yaml:"content,omitempty"`      // Text split into items on list markers
+	Ordered     bool     `yaml:"ordered,omitempty"`      // Number the items instead of bullets
+	Start       int      `yaml:"start,omitempty"`        // Number of the first item of an ordered list
+	Bullet      string   `yaml:"bullet,omitempty"`       // Glyph of unordered items
+	Indent      float64  `yaml:"indent,omitempty"`       // Hanging indent of the items in millimeters
+	ItemSpacing float64  `yaml:"item_spacing,omitempty"` // Space between items in millimeters
+	Size        float64  `yaml:"size,omitempty"`
+	Style       string   `yaml:"style,omitempty"` // named style, or normal, bold, italic, bolditalic
+	Color       *Color   `yaml:"color,omitempty"`
+	Markdown    bool     `yaml:"markdown,omitempty"`
+}
+
+// LineProp defines properties for a line in a column: a horizontal line across the middle of
+// the row, or a vertical separator down the middle of the column.
 type LineProp struct {
 	Thickness float64 `yaml:"thickness"`
 	Color     *Color  `yaml:"color,omitempty"`
+	Style     string  `yaml:"style,omitempty"`     // named style whose color the line uses
+	Direction string  `yaml:"direction,omitempty"` // horizontal (default) or vertical
+}
+
+// ShapeProp defines a rectangle, or a rounded rectangle when it has a radius, in a column.
+// A shape with a label and no width fits the label, which makes a tag "pill".
+type ShapeProp struct {
+	Width       float64   `yaml:"width,omitempty"`  // Width in millimeters; defaults to the column or label width
+	Height      float64   `yaml:"height,omitempty"` // Height in millimeters; defaults to the row or label height
+	Radius      float64   `yaml:"radius,omitempty"` // Corner radius in millimeters
+	Fill        *Color    `yaml:"fill,omitempty"`
+	Border      *Color    `yaml:"border,omitempty"`
+	BorderWidth float64   `yaml:"border_width,omitempty"`
+	Align       string    `yaml:"align,omitempty"`   // left, center, right within the column
+	Padding     float64   `yaml:"padding,omitempty"` // Space around the label in millimeters
+	Label       *TextProp `yaml:"label,omitempty"`   // Single line of text centered in the shape
+}
+
+// RatingProp defines a level, such as the level of a skill, shown as a progress bar or a row of dots.
+type RatingProp struct {
+	Kind   string  `yaml:"kind,omitempty"`   // bar (default) or dots
+	Value  float64 `yaml:"value"`            // Level between 0 and max
+	Max    float64 `yaml:"max,omitempty"`    // Highest level, 10 by default
+	Count  int     `yaml:"count,omitempty"`  // Number of dots, 5 by default
+	Size   float64 `yaml:"size,omitempty"`   // Bar height or dot diameter in millimeters
+	Gap    float64 `yaml:"gap,omitempty"`    // Space between dots in millimeters
+	Radius float64 `yaml:"radius,omitempty"` // Corner radius of the bar in millimeters
+	Color  *Color  `yaml:"color,omitempty"`  // Filled part
+	Track  *Color  `yaml:"track,omitempty"`  // Unfilled part
+	Style  string  `yaml:"style,omitempty"`  // named style whose color the filled part uses
+}
+
+// RadarProp defines a radar (spider) chart of levels, such as the levels of the top skills.
+type RadarProp struct {
+	Items []RadarItem `yaml:"items"`
+	Max   float64     `yaml:"max,omitempty"`   // Highest level, 10 by default
+	Rings int         `yaml:"rings,omitempty"` // Number of grid rings, 5 by default
+	Size  float64     `yaml:"size,omitempty"`  // Diameter of the chart in millimeters
+	Color *Color      `yaml:"color,omitempty"` // Outline of the levels
+	Fill  *Color      `yaml:"fill,omitempty"`  // Area of the levels, drawn translucent
+	Grid  *Color      `yaml:"grid,omitempty"`  // Rings and spokes
+	Style string      `yaml:"style,omitempty"` // named style whose color the levels use
+	Label *TextProp   `yaml:"label,omitempty"` // Size, style and color of the item labels
 }
 
-// ImageProp defines properties for an image in a column.
+// RadarItem is an axis of a radar chart.
+type RadarItem struct {
+	Label string  `yaml:"label"`
+	Value float64 `yaml:"value"`
+}
+
+// ImageProp defines properties for an image in a column. Images are PNG, JPEG, GIF or SVG files.
 type ImageProp struct {
 	Path    string  `yaml:"path"`
 	Percent float64 `yaml:"percent,omitempty"` // Size as percentage of column width
 	Center  bool    `yaml:"center,omitempty"`  // Whether to center the image
+	// MaxHeight scales the image down to at most this height in millimeters
+	MaxHeight float64 `yaml:"max_height,omitempty"`
+	DPI       int     `yaml:"dpi,omitempty"`    // Resolution SVG images are rasterised at, 300 by default
+	Vector    bool    `yaml:"vector,omitempty"` // Draws SVG images as vector paths instead of rasterising them
 }
 
-// Color represents an RGB color value.
+// Color represents an RGB color value, written in templates as a mapping of its components or
+// as a "#rrggbb" or "#rgb" string.
 type Color struct {
 	Red   int `yaml:"red"`
 	Green int `yaml:"green"`
 	Blue  int `yaml:"blue"`
 }
 
+// UnmarshalYAML decodes a color from a mapping of its components or from a hex string.
+func (c *Color) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		parsed, err := parseHexColor(value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*c = parsed
+		return nil
+	}
+	type plain Color
+	return value.Decode((*plain)(c))
+}
+
+// parseHexColor parses a "#rrggbb" or "#rgb" color.
+func parseHexColor(s string) (Color, error) {
+	hex, ok := strings.CutPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if !ok || len(hex) != 6 || err != nil {
+		return Color{}, fmt.Errorf("want a #rrggbb color, got %q", s)
+	}
+	return Color{Red: int(n >> 16), Green: int(n >> 8 & 0xff), Blue: int(n & 0xff)}, nil
+}
+
+// hex returns the color as a "#rrggbb" string.
+func (c Color) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.Red, c.Green, c.Blue)
+}
+
 // ParseTemplate reads a YAML template file, executes it as a Go template with the provided data,
 // and parses the resulting YAML into a Template structure.
-// Returns an error if the file cannot be read, the template cannot be parsed or executed,
+// The partial template files are parsed after the template, in order, so the templates they
+// define can be used with {{template}} and replace the {{block}} defaults of the template and
+// the definitions of earlier partials.
+// Returns an error if a file cannot be read, the template cannot be parsed or executed,
 // or the resulting YAML is invalid.
-func ParseTemplate(path string, data interface{}, funcs template.FuncMap) (*Template, error) {
+func ParseTemplate(path string, data interface{}, funcs template.FuncMap, partials ...string) (*Template, error) {
 	tmplContent, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read template file: %w", err)
@@ -76,18 +279,132 @@ func ParseTemplate(path string, data interface{}, funcs template.FuncMap) (*Temp
 	if err != nil {
 		return nil, fmt.Errorf("parse template: %w", err)
 	}
+	for _, partial := range partials {
+		content, err := os.ReadFile(partial)
+		if err != nil {
+			return nil, fmt.Errorf("read partial: %w", err)
+		}
+		if _, err := tmpl.New(filepath.Base(partial)).Parse(string(content)); err != nil {
+			return nil, fmt.Errorf("parse partial: %w", err)
+		}
+	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
 		return nil, fmt.Errorf("execute template: %w", err)
 	}
 
-	var t Template
+	t := Template{Page: defaultPage()}
 	if err := yaml.Unmarshal(buf.Bytes(), &t); err != nil {
 		// Log the generated YAML for debugging purposes if parsing fails
 		fmt.Println("Generated YAML:\n", buf.String())
 		return nil, fmt.Errorf("unmarshal YAML: %w", err)
 	}
 
+	if _, err := t.Page.layout(""); err != nil {
+		return nil, fmt.Errorf("page: %w", err)
+	}
+	if err := t.checkSidebar(); err != nil {
+		return nil, err
+	}
+	if err := t.checkStyles(); err != nil {
+		return nil, err
+	}
+	if err := t.checkShapes(); err != nil {
+		return nil, err
+	}
+	if err := t.checkCharts(); err != nil {
+		return nil, err
+	}
+	if err := t.checkRows(); err != nil {
+		return nil, err
+	}
+	if err := t.checkFit(); err != nil {
+		return nil, err
+	}
+
 	return &t, nil
 }
+
+// split divides a page region into the region of the main rows and the region of the sidebar.
+func (s *Sidebar) split(page region) (main, sidebar region) {
+	width := page.colWidth(s.Width)
+	main = region{x: page.x + width + s.Gap, width: page.width - width - s.Gap}
+	sidebar = region{x: page.x, width: width}
+	if strings.EqualFold(s.Side, "right") {
+		main.x = page.x
+		sidebar.x = page.x + page.width - width
+	}
+	return main, sidebar
+}
+
+// checkSidebar verifies that the sidebar leaves room for the main rows.
+func (t *Template) checkSidebar() error {
+	if t.Sidebar == nil {
+		return nil
+	}
+	if t.Sidebar.Width < 1 || t.Sidebar.Width >= marotoCols {
+		return fmt.Errorf("sidebar: width must be between 1 and %d columns, got %d", int(marotoCols)-1, t.Sidebar.Width)
+	}
+	switch strings.ToLower(t.Sidebar.Side) {
+	case "", "left", "right":
+		return nil
+	default:
+		return fmt.Errorf("sidebar: side must be left or right, got %q", t.Sidebar.Side)
+	}
+}
+
+// walkRows calls fn for every row of the template, including the rows of the sidebar, the header,
+// the footer, groups and nested grids, with a path locating the row. Walking stops when fn returns false.
+func (t *Template) walkRows(fn func(path string, r *Row) bool) {
+	var walk func(prefix string, rows []Row) bool
+	walk = func(prefix string, rows []Row) bool {
+		for i := range rows {
+			r := &rows[i]
+			path := fmt.Sprintf("%srow %d", prefix, i+1)
+			if !fn(path, r) || !walk(path+", ", r.Rows) {
+				return false
+			}
+			for j := range r.Cols {
+				if !walk(fmt.Sprintf("%s, column %d, ", path, j+1), r.Cols[j].Rows) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	if !walk("", t.Rows) {
+		return
+	}
+	if t.Sidebar != nil && !walk("sidebar, ", t.Sidebar.Rows) {
+		return
+	}
+	// The header and footer are walked as one-row lists, and take back the changes of fn
+	walkOne := func(prefix string, r *Row) bool {
+		rows := []Row{*r}
+		ok := walk(prefix, rows)
+		*r = rows[0]
+		return ok
+	}
+	if t.Header != nil && !walkOne("header, ", t.Header) {
+		return
+	}
+	if t.Footer != nil {
+		walkOne("footer, ", t.Footer)
+	}
+}
+
+// walkCols calls fn for every column of the template, including the columns of the sidebar, the
+// header, the footer, groups and nested rows, with a path locating the column. Walking stops when
+// fn returns false.
+func (t *Template) walkCols(fn func(path string, col *Col) bool) {
+	t.walkRows(func(path string, r *Row) bool {
+		for j := range r.Cols {
+			if !fn(fmt.Sprintf("%s, column %d", path, j+1), &r.Cols[j]) {
+				return false
+			}
+		}
+		return true
+	})
+}
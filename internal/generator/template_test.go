@@ -7,6 +7,7 @@ import (
 	"text/template"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestParseTemplate(t *testing.T) {
@@ -62,3 +63,131 @@ rows:
 		assert.Error(t, err)
 	})
 }
+
+func TestSidebar_Split(t *testing.T) {
+	page := region{x: 10, width: 120}
+
+	main, sidebar := (&Sidebar{Width: 4, Gap: 5}).split(page)
+	assert.Equal(t, region{x: 10, width: 40}, sidebar)
+	assert.Equal(t, region{x: 55, width: 75}, main)
+
+	main, sidebar = (&Sidebar{Width: 3, Side: "right"}).split(page)
+	assert.Equal(t, region{x: 100, width: 30}, sidebar)
+	assert.Equal(t, region{x: 10, width: 90}, main)
+}
+
+func TestTemplate_CheckSidebar(t *testing.T) {
+	assert.NoError(t, (&Template{}).checkSidebar())
+	assert.NoError(t, (&Template{Sidebar: &Sidebar{Width: 4, Side: "Right"}}).checkSidebar())
+	assert.Error(t, (&Template{Sidebar: &Sidebar{Width: 12}}).checkSidebar())
+	assert.Error(t, (&Template{Sidebar: &Sidebar{Width: 4, Side: "top"}}).checkSidebar())
+}
+
+func TestParseTemplate_Page(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(tempDir, "resume.yaml.tmpl")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create template file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		tmpl, err := ParseTemplate(write("rows: []\n"), nil, template.FuncMap{})
+		assert.NoError(t, err)
+		assert.Equal(t, defaultPage(), tmpl.Page)
+	})
+
+	t.Run("Declared settings keep the other defaults", func(t *testing.T) {
+		tmpl, err := ParseTemplate(write("page:\n  size: Letter\n  margins:\n    bottom: 15\nrows: []\n"), nil, template.FuncMap{})
+		assert.NoError(t, err)
+		assert.Equal(t, Page{
+			Size:        "Letter",
+			Orientation: "portrait",
+			Margins:     Margins{Top: 10, Right: 10, Bottom: 15, Left: 10},
+		}, tmpl.Page)
+	})
+
+	t.Run("Invalid page size", func(t *testing.T) {
+		_, err := ParseTemplate(write("page:\n  size: huge\nrows: []\n"), nil, template.FuncMap{})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTemplate_Partials(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create template file: %v", err)
+		}
+		return path
+	}
+
+	tmplPath := write("resume.yaml.tmpl", `
+rows:
+  {{template "entry" .Name}}
+  {{block "extra" .}}
+  - cols: []
+  {{end}}
+`)
+	entry := write("entry.tmpl", `{{define "entry"}}
+  - cols:
+      - width: 12
+        text:
+          content: "{{.}}"
+{{end}}`)
+	override := write("override.tmpl", `{{define "entry"}}
+  - cols:
+      - width: 12
+        text:
+          content: "Dr. {{.}}"
+{{end}}
+{{define "extra"}}
+  - height: 5
+    cols: []
+{{end}}`)
+	data := struct{ Name string }{Name: "John Doe"}
+
+	t.Run("Templates of partials", func(t *testing.T) {
+		tmpl, err := ParseTemplate(tmplPath, data, template.FuncMap{}, entry)
+		assert.NoError(t, err)
+		assert.Len(t, tmpl.Rows, 2)
+		assert.Equal(t, "John Doe", tmpl.Rows[0].Cols[0].Text.Content)
+		assert.Zero(t, tmpl.Rows[1].Height, "the block keeps its default")
+	})
+
+	t.Run("Later partials replace definitions and blocks", func(t *testing.T) {
+		tmpl, err := ParseTemplate(tmplPath, data, template.FuncMap{}, entry, override)
+		assert.NoError(t, err)
+		assert.Len(t, tmpl.Rows, 2)
+		assert.Equal(t, "Dr. John Doe", tmpl.Rows[0].Cols[0].Text.Content)
+		assert.Equal(t, 5.0, tmpl.Rows[1].Height)
+	})
+
+	t.Run("Missing partial", func(t *testing.T) {
+		_, err := ParseTemplate(tmplPath, data, template.FuncMap{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid partial", func(t *testing.T) {
+		_, err := ParseTemplate(tmplPath, data, template.FuncMap{}, write("invalid.tmpl", "{{define \"entry\"}}"))
+		assert.Error(t, err)
+	})
+}
+
+func TestColor_UnmarshalYAML(t *testing.T) {
+	var styles map[string]TextStyle
+	err := yaml.Unmarshal([]byte("a: {color: '#005AA0'}\nb: {color: '#fff'}\nc: {color: {red: 1, green: 2, blue: 3}}\n"), &styles)
+	assert.NoError(t, err)
+	assert.Equal(t, &Color{Red: 0, Green: 90, Blue: 160}, styles["a"].Color)
+	assert.Equal(t, &Color{Red: 255, Green: 255, Blue: 255}, styles["b"].Color)
+	assert.Equal(t, &Color{Red: 1, Green: 2, Blue: 3}, styles["c"].Color)
+
+	for _, bad := range []string{"005aa0", "#05aa0", "#gggggg", "blue"} {
+		err := yaml.Unmarshal([]byte("a: {color: '"+bad+"'}\n"), &styles)
+		assert.Error(t, err, bad)
+	}
+	assert.Equal(t, "#005aa0", Color{Red: 0, Green: 90, Blue: 160}.hex())
+}
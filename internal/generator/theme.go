@@ -0,0 +1,377 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// themeManifestFile is the optional manifest in the directory of a theme.
+	themeManifestFile = "theme.yaml"
+	// partialsDir is the directory of the templates directory with partials shared by every theme.
+	partialsDir = "_partials"
+	// pdfTemplateFile is the template of the PDF resume in the directory of a theme.
+	pdfTemplateFile = "resume.yaml.tmpl"
+	// websiteTemplateFile is the template of the home page of the website in the directory of a theme.
+	websiteTemplateFile = "index.html.tmpl"
+	// pageTemplateSuffix ends the names of the templates of the website pages.
+	pageTemplateSuffix = ".html.tmpl"
+	// themeAssetsDir is the directory of a theme with the assets it publishes with the website.
+	themeAssetsDir = "assets"
+)
+
+// ThemeManifest describes a theme in the theme.yaml file of its directory.
+type ThemeManifest struct {
+	// Extends names the parent theme. Files missing from the theme, and the partials and
+	// components it does not define, resolve from the parent and the themes it extends.
+	Extends string `yaml:"extends,omitempty"`
+	// Options declares the options of the theme, by name. A theme inherits the options of the
+	// themes it extends and may redeclare them, for example with another default.
+	Options map[string]ThemeOption `yaml:"options,omitempty"`
+}
+
+// ThemeOption declares an option of a theme that the configuration sets for a site.
+type ThemeOption struct {
+	// Type is one of string, bool, int, number, color ("#rrggbb") or list (of strings).
+	Type string `yaml:"type"`
+	// Default is the value of the option when the configuration does not set it.
+	Default any `yaml:"default,omitempty"`
+	// Values restricts a string, or the items of a list, to these values when not empty.
+	Values []string `yaml:"values,omitempty"`
+	// Min is the smallest value of an int or number option, when set.
+	Min *float64 `yaml:"min,omitempty"`
+	// Description documents the option for the users of the theme.
+	Description string `yaml:"description,omitempty"`
+}
+
+// ThemeOptions are the values of the options of a theme by name: a string, bool, int, float64,
+// "#rrggbb" string or []string, as declared by the option type.
+type ThemeOptions map[string]any
+
+// get returns the value of an option, for templates that cannot reach the options in their data.
+func (o ThemeOptions) get(name string) (any, error) {
+	value, ok := o[name]
+	if !ok {
+		return nil, fmt.Errorf("theme has no option %s", name)
+	}
+	return value, nil
+}
+
+// loadThemeManifest reads the manifest of a theme. A theme without a manifest extends no theme.
+func loadThemeManifest(templatesDir, theme string) (ThemeManifest, error) {
+	var m ThemeManifest
+	content, err := os.ReadFile(filepath.Join(templatesDir, theme, themeManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return m, fmt.Errorf("read theme manifest: %w", err)
+	}
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return m, fmt.Errorf("theme %s: parse %s: %w", theme, themeManifestFile, err)
+	}
+	return m, nil
+}
+
+// themeChain returns a theme followed by the themes it extends, nearest first.
+func themeChain(templatesDir, theme string) ([]string, error) {
+	var chain []string
+	for name := theme; name != ""; {
+		if slices.Contains(chain, name) {
+			return nil, fmt.Errorf("theme %s: extends itself through %s", theme, strings.Join(append(chain, name), " -> "))
+		}
+		if info, err := os.Stat(filepath.Join(templatesDir, name)); err != nil || !info.IsDir() {
+			if name == theme {
+				return nil, fmt.Errorf("theme %s not found in %s", name, templatesDir)
+			}
+			return nil, fmt.Errorf("theme %s: parent theme %s not found in %s", chain[len(chain)-1], name, templatesDir)
+		}
+		chain = append(chain, name)
+
+		m, err := loadThemeManifest(templatesDir, name)
+		if err != nil {
+			return nil, err
+		}
+		name = m.Extends
+	}
+	return chain, nil
+}
+
+// resolveThemeOptions returns the options declared by a theme and the themes it extends, set to
+// the configured values or to their defaults. Configured values are native YAML values or
+// strings, such as "true" or "a,b,c" given on the command line, and must match the type of their
+// option. Values of options the theme does not declare are an error.
+func resolveThemeOptions(templatesDir, theme string, values map[string]any) (ThemeOptions, error) {
+	chain, err := themeChain(templatesDir, theme)
+	if err != nil {
+		return nil, err
+	}
+	declared := make(map[string]ThemeOption)
+	for i := len(chain) - 1; i >= 0; i-- {
+		m, err := loadThemeManifest(templatesDir, chain[i])
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(declared, m.Options)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if _, ok := declared[name]; !ok {
+			return nil, fmt.Errorf("theme %s has no option %s", theme, name)
+		}
+	}
+
+	options := make(ThemeOptions, len(declared))
+	for _, name := range slices.Sorted(maps.Keys(declared)) {
+		opt := declared[name]
+		value, err := opt.parse(opt.Default)
+		if err != nil {
+			return nil, fmt.Errorf("theme %s: option %s: default: %w", theme, name, err)
+		}
+		if configured, ok := values[name]; ok {
+			if value, err = opt.parse(configured); err != nil {
+				return nil, fmt.Errorf("theme option %s: %w", name, err)
+			}
+		}
+		options[name] = value
+	}
+	return options, nil
+}
+
+// ValidateThemeOptions checks configured option values against the options declared by a theme
+// and the themes it extends, so that a bad value is reported before anything is generated.
+func ValidateThemeOptions(templatesDir, theme string, values map[string]any) error {
+	_, err := resolveThemeOptions(templatesDir, theme, values)
+	return err
+}
+
+// parse converts a value to the type of the option and checks it against the allowed values. A
+// nil value is the zero value of the type.
+func (o ThemeOption) parse(v any) (any, error) {
+	switch o.Type {
+	case "string":
+		s := ""
+		if v != nil {
+			s = fmt.Sprint(v)
+		}
+		return s, o.check(s)
+	case "bool":
+		switch b := v.(type) {
+		case nil:
+			return false, nil
+		case bool:
+			return b, nil
+		case string:
+			parsed, err := strconv.ParseBool(b)
+			if err != nil {
+				return nil, fmt.Errorf("want a boolean, got %q", b)
+			}
+			return parsed, nil
+		}
+		return nil, fmt.Errorf("want a boolean, got %v", v)
+	case "int":
+		switch n := v.(type) {
+		case nil:
+			return 0, nil
+		case int:
+			return n, o.checkMin(float64(n))
+		case string:
+			parsed, err := strconv.Atoi(n)
+			if err != nil {
+				return nil, fmt.Errorf("want an integer, got %q", n)
+			}
+			return parsed, o.checkMin(float64(parsed))
+		}
+		return nil, fmt.Errorf("want an integer, got %v", v)
+	case "number":
+		switch n := v.(type) {
+		case nil:
+			return 0.0, nil
+		case int:
+			return float64(n), o.checkMin(float64(n))
+		case float64:
+			return n, o.checkMin(n)
+		case string:
+			parsed, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				return nil, fmt.Errorf("want a number, got %q", n)
+			}
+			return parsed, o.checkMin(parsed)
+		}
+		return nil, fmt.Errorf("want a number, got %v", v)
+	case "color":
+		if v == nil {
+			return Color{}.hex(), nil
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("want a #rrggbb color, got %v", v)
+		}
+		c, err := parseHexColor(s)
+		if err != nil {
+			return nil, err
+		}
+		return c.hex(), nil
+	case "list":
+		var items []string
+		switch l := v.(type) {
+		case nil:
+		case []string:
+			items = l
+		case []any:
+			for _, item := range l {
+				items = append(items, fmt.Sprint(item))
+			}
+		case string:
+			for item := range strings.SplitSeq(l, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("want a list, got %v", v)
+		}
+		if items == nil {
+			items = []string{}
+		}
+		for _, item := range items {
+			if err := o.check(item); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unknown type %q", o.Type)
+}
+
+// check verifies that a string is one of the allowed values of the option, if it has any.
+func (o ThemeOption) check(s string) error {
+	if len(o.Values) > 0 && !slices.Contains(o.Values, s) {
+		return fmt.Errorf("%q is not one of %s", s, strings.Join(o.Values, ", "))
+	}
+	return nil
+}
+
+// checkMin verifies that a number is not below the minimum of the option, if it has one.
+func (o ThemeOption) checkMin(n float64) error {
+	if o.Min != nil && n < *o.Min {
+		return fmt.Errorf("%v is less than %v", n, *o.Min)
+	}
+	return nil
+}
+
+// themeDirs returns the directories of a theme and of the themes it extends, nearest first.
+func themeDirs(templatesDir, theme string) ([]string, error) {
+	chain, err := themeChain(templatesDir, theme)
+	if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, len(chain))
+	for i, name := range chain {
+		dirs[i] = filepath.Join(templatesDir, name)
+	}
+	return dirs, nil
+}
+
+// findThemeFile returns the path of a file, relative to the theme directories, in the nearest
+// of them that has it.
+func findThemeFile(dirs []string, name string) (string, bool) {
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// themePartials returns the partials of a PDF theme in the order they are parsed, so that later
+// definitions replace earlier ones: the shared partials, then the partials of the themes it
+// extends, farthest first, and then its own. Partials are the *.tmpl files of the directories
+// besides the PDF template and the *.html.tmpl website templates.
+func themePartials(templatesDir string, dirs []string) ([]string, error) {
+	search := []string{filepath.Join(templatesDir, partialsDir)}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		search = append(search, dirs[i])
+	}
+
+	var partials []string
+	for _, dir := range search {
+		files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("find partials: %w", err)
+		}
+		for _, f := range files {
+			name := filepath.Base(f)
+			if name == pdfTemplateFile || strings.HasSuffix(name, pageTemplateSuffix) {
+				continue
+			}
+			partials = append(partials, f)
+		}
+	}
+	return partials, nil
+}
+
+// themeFS is a file system of the directories of a theme and of the themes it extends: a file
+// resolves from the nearest theme that has it, and a directory lists the entries of all of them.
+type themeFS struct {
+	dirs []fs.FS // Nearest first
+}
+
+// newThemeFS returns the file system of the theme directories, given nearest first.
+func newThemeFS(dirs []string) *themeFS {
+	t := &themeFS{}
+	for _, dir := range dirs {
+		t.dirs = append(t.dirs, os.DirFS(dir))
+	}
+	return t
+}
+
+// Open opens the named file of the nearest theme that has it.
+func (t *themeFS) Open(name string) (fs.File, error) {
+	for _, dir := range t.dirs {
+		f, err := dir.Open(name)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir lists the entries of the named directory in every theme, sorted by name. An entry
+// present in several themes is the one of the nearest theme.
+func (t *themeFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	seen := make(map[string]bool)
+	found := false
+	for _, dir := range t.dirs {
+		list, err := fs.ReadDir(dir, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, e := range list {
+			if !seen[e.Name()] {
+				seen[e.Name()] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
@@ -0,0 +1,264 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+// writeTheme creates the files of a theme, given by name and content, in a templates directory.
+func writeTheme(t *testing.T, templatesDir, theme string, files map[string]string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(templatesDir, theme), 0755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(templatesDir, theme, name), []byte(content), 0644))
+	}
+}
+
+func TestThemeChain(t *testing.T) {
+	templatesDir := t.TempDir()
+	writeTheme(t, templatesDir, "default", nil)
+	writeTheme(t, templatesDir, "company", map[string]string{themeManifestFile: "extends: default\n"})
+	writeTheme(t, templatesDir, "team", map[string]string{themeManifestFile: "extends: company\n"})
+	writeTheme(t, templatesDir, "orphan", map[string]string{themeManifestFile: "extends: missing\n"})
+	writeTheme(t, templatesDir, "ping", map[string]string{themeManifestFile: "extends: pong\n"})
+	writeTheme(t, templatesDir, "pong", map[string]string{themeManifestFile: "extends: ping\n"})
+	writeTheme(t, templatesDir, "broken", map[string]string{themeManifestFile: "extends: [default]\n"})
+
+	chain, err := themeChain(templatesDir, "team")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team", "company", "default"}, chain)
+
+	chain, err = themeChain(templatesDir, "default")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default"}, chain)
+
+	for _, theme := range []string{"missing", "orphan", "ping", "broken"} {
+		_, err := themeChain(templatesDir, theme)
+		assert.Error(t, err, theme)
+	}
+}
+
+func TestThemePartials(t *testing.T) {
+	templatesDir := t.TempDir()
+	writeTheme(t, templatesDir, partialsDir, map[string]string{"sections.tmpl": ""})
+	writeTheme(t, templatesDir, "default", map[string]string{
+		pdfTemplateFile:   "",
+		"index.html.tmpl": "",
+		"components.tmpl": "",
+		"notes.txt":       "",
+	})
+	writeTheme(t, templatesDir, "company", map[string]string{
+		themeManifestFile: "extends: default\n",
+		"components.tmpl": "",
+		"brand.tmpl":      "",
+	})
+
+	dirs, err := themeDirs(templatesDir, "company")
+	require.NoError(t, err)
+	partials, err := themePartials(templatesDir, dirs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(templatesDir, partialsDir, "sections.tmpl"),
+		filepath.Join(templatesDir, "default", "components.tmpl"),
+		filepath.Join(templatesDir, "company", "brand.tmpl"),
+		filepath.Join(templatesDir, "company", "components.tmpl"),
+	}, partials, "shared partials first and the theme's own last")
+}
+
+func TestThemeFS(t *testing.T) {
+	templatesDir := t.TempDir()
+	writeTheme(t, templatesDir, "default", map[string]string{"index.html.tmpl": "parent", "header.html": "parent header"})
+	writeTheme(t, templatesDir, "company", map[string]string{themeManifestFile: "extends: default\n", "header.html": "company header"})
+
+	dirs, err := themeDirs(templatesDir, "company")
+	require.NoError(t, err)
+	fsys := newThemeFS(dirs)
+
+	content, err := fs.ReadFile(fsys, "header.html")
+	assert.NoError(t, err)
+	assert.Equal(t, "company header", string(content), "the theme overrides its parent")
+	content, err = fs.ReadFile(fsys, "index.html.tmpl")
+	assert.NoError(t, err)
+	assert.Equal(t, "parent", string(content), "missing files resolve from the parent")
+	_, err = fs.ReadFile(fsys, "missing.html")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	entries, err := fs.ReadDir(fsys, ".")
+	assert.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal(t, []string{"header.html", "index.html.tmpl", themeManifestFile}, names)
+
+	path, ok := findThemeFile(dirs, "index.html.tmpl")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(templatesDir, "default", "index.html.tmpl"), path)
+	_, ok = findThemeFile(dirs, "missing.html")
+	assert.False(t, ok)
+}
+
+func TestPDFGenerator_ParseExtendedTemplate(t *testing.T) {
+	templatesDir := t.TempDir()
+	writeTheme(t, templatesDir, "default", map[string]string{
+		pdfTemplateFile: `
+styles:
+  heading:
+    color: {{block "accent-color" .}}{red: 105, green: 190, blue: 40}{{end}}
+rows:
+  {{template "header" .}}
+`,
+		"components.tmpl": `{{define "header"}}
+  - cols:
+      - width: 12
+        text:
+          content: "{{.Basic.Name}}"
+          style: heading
+{{end}}`,
+	})
+	writeTheme(t, templatesDir, "company", map[string]string{
+		themeManifestFile: "extends: default\n",
+		"brand.tmpl":      `{{define "accent-color"}}{red: 0, green: 90, blue: 160}{{end}}`,
+	})
+	data := &models.ResumeData{Basic: models.BasicData{Name: "John Doe"}}
+
+	pg, err := NewPDFGenerator(t.TempDir(), templatesDir, "company")
+	require.NoError(t, err)
+	tmpl, err := pg.parseTemplate(data)
+	require.NoError(t, err)
+	assert.Equal(t, &Color{Red: 0, Green: 90, Blue: 160}, tmpl.Styles["heading"].Color, "the theme overrides a block")
+	assert.Equal(t, "John Doe", tmpl.Rows[0].Cols[0].Text.Content, "the template and components resolve from the parent")
+
+	pg, err = NewPDFGenerator(t.TempDir(), templatesDir, "missing")
+	require.NoError(t, err)
+	_, err = pg.parseTemplate(data)
+	assert.Error(t, err)
+}
+
+func TestResolveThemeOptions(t *testing.T) {
+	templatesDir := t.TempDir()
+	writeTheme(t, templatesDir, "default", map[string]string{themeManifestFile: `
+options:
+  accent_color: {type: color, default: "#69BE28"}
+  show_photo: {type: bool, default: true}
+  columns: {type: int, default: 2, min: 1}
+  scale: {type: number, default: 1, min: 0.5}
+  section_order: {type: list, default: [summary, skills], values: [summary, experience, skills]}
+  date_format: {type: string, default: Jan 2006}
+`})
+	writeTheme(t, templatesDir, "company", map[string]string{themeManifestFile: `
+extends: default
+options:
+  accent_color: {type: color, default: "#005aa0"}
+  tagline: {type: string, default: short, values: [short, long]}
+`})
+	writeTheme(t, templatesDir, "broken", map[string]string{themeManifestFile: "options:\n  size: {type: int, default: big}\n"})
+	writeTheme(t, templatesDir, "unknown", map[string]string{themeManifestFile: "options:\n  size: {type: length}\n"})
+
+	options, err := resolveThemeOptions(templatesDir, "company", nil)
+	require.NoError(t, err)
+	assert.Equal(t, ThemeOptions{
+		"accent_color":  "#005aa0",
+		"show_photo":    true,
+		"columns":       2,
+		"scale":         1.0,
+		"section_order": []string{"summary", "skills"},
+		"date_format":   "Jan 2006",
+		"tagline":       "short",
+	}, options, "the theme redeclares and adds to the options of its parent")
+
+	options, err = resolveThemeOptions(templatesDir, "company", map[string]any{
+		"accent_color":  "#ABC",
+		"show_photo":    "false",
+		"columns":       "3",
+		"scale":         0.9,
+		"section_order": "skills, experience",
+		"tagline":       "long",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "#aabbcc", options["accent_color"])
+	assert.Equal(t, false, options["show_photo"])
+	assert.Equal(t, 3, options["columns"])
+	assert.Equal(t, 0.9, options["scale"])
+	assert.Equal(t, []string{"skills", "experience"}, options["section_order"])
+	assert.Equal(t, "long", options["tagline"])
+
+	options, err = resolveThemeOptions(templatesDir, "default", map[string]any{"section_order": []any{"experience"}, "show_photo": false})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"experience"}, options["section_order"], "configured lists are YAML sequences")
+	assert.Equal(t, false, options["show_photo"])
+
+	invalid := []map[string]any{
+		{"missing": "x"},
+		{"accent_color": "green"},
+		{"show_photo": "maybe"},
+		{"columns": "two"},
+		{"columns": 0},
+		{"scale": "0.25"},
+		{"scale": []any{1}},
+		{"section_order": "summary,footer"},
+		{"tagline": "medium"},
+	}
+	for _, values := range invalid {
+		_, err := resolveThemeOptions(templatesDir, "company", values)
+		assert.Error(t, err, values)
+	}
+	for _, theme := range []string{"broken", "unknown", "missing"} {
+		_, err := resolveThemeOptions(templatesDir, theme, nil)
+		assert.Error(t, err, theme)
+	}
+}
+
+func TestPDFGenerator_ParseTemplateThemeOptions(t *testing.T) {
+	templatesDir := t.TempDir()
+	writeTheme(t, templatesDir, "default", map[string]string{
+		themeManifestFile: `
+options:
+  accent_color: {type: color, default: "#69be28"}
+  date_format: {type: string, default: Jan 2006}
+`,
+		pdfTemplateFile: `
+styles:
+  heading:
+    color: "{{.Theme.accent_color}}"
+rows:
+  - cols:
+      - width: 12
+        text:
+          content: "{{getEmail .}}"
+  {{range .Professional.Jobs}}{{template "job" .}}{{end}}
+`,
+		"components.tmpl": `{{define "job"}}
+  - cols:
+      - width: 12
+        text:
+          content: "{{formatDate .StartDate (theme "date_format")}}"
+{{end}}`,
+	})
+	start := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	data := &models.ResumeData{
+		Social:       []models.Entity{{Name: "Email", URL: "mailto:john@example.com"}},
+		Professional: models.ProfessionalData{Jobs: []models.Job{{StartDate: start}}},
+	}
+
+	pg, err := NewPDFGenerator(t.TempDir(), templatesDir, "default", WithPDFThemeOptions(map[string]any{"accent_color": "#005aa0", "date_format": "01/2006"}))
+	require.NoError(t, err)
+	tmpl, err := pg.parseTemplate(data)
+	require.NoError(t, err)
+	assert.Equal(t, &Color{Red: 0, Green: 90, Blue: 160}, tmpl.Styles["heading"].Color)
+	assert.Equal(t, "john@example.com", tmpl.Rows[0].Cols[0].Text.Content, "functions of the resume data take the template data")
+	assert.Equal(t, "03/2020", tmpl.Rows[1].Cols[0].Text.Content, "components read options with the theme function")
+
+	pg, err = NewPDFGenerator(t.TempDir(), templatesDir, "default", WithPDFThemeOptions(map[string]any{"accent_color": "blue"}))
+	require.NoError(t, err)
+	_, err = pg.parseTemplate(data)
+	assert.Error(t, err)
+}
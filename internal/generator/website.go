@@ -1,9 +1,13 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/open2b/scriggo"
 	"github.com/open2b/scriggo/native"
@@ -18,15 +22,56 @@ type WebsiteGenerator struct {
 	templatesDir string
 	theme        string
 	assetsDir    string
+	dataExports  bool
+	themeValues  map[string]any // Configured values of the theme options
+	posts        []models.Post  // Posts of the blog, newest first
+	drafts       bool           // Whether draft posts are published
+}
+
+// WebsiteOption configures optional WebsiteGenerator behaviour
+type WebsiteOption func(*WebsiteGenerator)
+
+// WithDataExports publishes resume.json and data.json next to each index.html
+func WithDataExports(enabled bool) WebsiteOption {
+	return func(wg *WebsiteGenerator) {
+		wg.dataExports = enabled
+	}
+}
+
+// WithWebsiteThemeOptions sets options declared in the theme.yaml of the theme, or of the themes
+// it extends, that templates read as Theme. Options left unset keep their defaults.
+func WithWebsiteThemeOptions(values map[string]any) WebsiteOption {
+	return func(wg *WebsiteGenerator) {
+		wg.themeValues = values
+	}
+}
+
+// WithPosts publishes the posts of the language in the blog of the website, with a page for each
+// post, a paginated index and an Atom feed
+func WithPosts(posts []models.Post) WebsiteOption {
+	return func(wg *WebsiteGenerator) {
+		wg.posts = posts
+	}
+}
+
+// WithDrafts publishes draft posts as well
+func WithDrafts(enabled bool) WebsiteOption {
+	return func(wg *WebsiteGenerator) {
+		wg.drafts = enabled
+	}
 }
 
 // NewWebsiteGenerator creates a new website generator
-func NewWebsiteGenerator(templatesDir, theme, assetsDir string) *WebsiteGenerator {
-	return &WebsiteGenerator{
+func NewWebsiteGenerator(templatesDir, theme, assetsDir string, opts ...WebsiteOption) *WebsiteGenerator {
+	wg := &WebsiteGenerator{
 		templatesDir: templatesDir,
 		theme:        theme,
 		assetsDir:    assetsDir,
 	}
+	for _, opt := range opts {
+		opt(wg)
+	}
+	return wg
 }
 
 // Generate generates the complete static website
@@ -38,9 +83,16 @@ func (wg *WebsiteGenerator) Generate(data *models.ResumeData, outputDir, lang st
 		return fmt.Errorf("failed to clear and create output directory: %w", err)
 	}
 
-	// Generate main pages
-	if err := wg.generateIndexPage(data, outputDir, lang); err != nil {
-		return fmt.Errorf("failed to generate index page: %w", err)
+	// Generate the pages of the theme
+	if err := wg.generatePages(data, outputDir, lang); err != nil {
+		return fmt.Errorf("failed to generate pages: %w", err)
+	}
+
+	// Publish machine-readable exports of the data
+	if wg.dataExports {
+		if err := writeDataExports(data, outputDir); err != nil {
+			return fmt.Errorf("failed to write data exports: %w", err)
+		}
 	}
 
 	// Copy static assets
@@ -54,21 +106,51 @@ func (wg *WebsiteGenerator) Generate(data *models.ResumeData, outputDir, lang st
 	return nil
 }
 
-// generateIndexPage generates the main index.html page
-func (wg *WebsiteGenerator) generateIndexPage(data *models.ResumeData, outputDir, lang string) error {
-	templatePath := filepath.Join(wg.templatesDir, wg.theme, "index.html.tmpl")
-
-	// Read template file
-	templateContent, err := os.ReadFile(templatePath)
+// generatePages renders every page template of the theme, and of the themes it extends, to its
+// own page. Templates resolve from the nearest theme that has them, so pages can extend layouts
+// and import or show files of any theme of the chain.
+func (wg *WebsiteGenerator) generatePages(data *models.ResumeData, outputDir, lang string) error {
+	dirs, err := themeDirs(wg.templatesDir, wg.theme)
 	if err != nil {
+		return err
+	}
+	tfs := newThemeFS(dirs)
+	if _, err := fs.Stat(tfs, websiteTemplateFile); err != nil {
 		return fmt.Errorf("failed to read template file: %w", err)
 	}
+	pages, err := themePages(tfs)
+	if err != nil {
+		return fmt.Errorf("failed to find page templates: %w", err)
+	}
+
+	options, err := resolveThemeOptions(wg.templatesDir, wg.theme, wg.themeValues)
+	if err != nil {
+		return err
+	}
+	// Templates select options as Theme.name, which Scriggo supports on unnamed map types only
+	theme := map[string]any(options)
+
+	// Pages link to the home page of their language
+	home := "/"
+	if lang != utils.DefaultLang {
+		home = "/" + lang + "/"
+	}
+
+	b, err := wg.newBlog(home, options)
+	if err != nil {
+		return err
+	}
 
 	// Define custom template functions and data
 	globals := native.Declarations{
 		"Data":        data,
 		"Lang":        lang,
 		"DefaultLang": utils.DefaultLang,
+		"Theme":       &theme,
+		"Home":        home,
+		"Posts":       &b.posts,
+		"Post":        &b.post,
+		"BlogPage":    &b.page,
 		"seq": func(n int) []int {
 			seq := make([]int, n)
 			for i := 0; i < n; i++ {
@@ -85,16 +167,67 @@ func (wg *WebsiteGenerator) generateIndexPage(data *models.ResumeData, outputDir
 		Globals: globals,
 	}
 
-	fs := scriggo.Files{
-		"index.html": templateContent,
+	fsys := scriggoFS{tfs}
+	for _, name := range pages {
+		if err := renderPage(fsys, name, filepath.Join(outputDir, pageOutputPath(name)), opts); err != nil {
+			return fmt.Errorf("page %s: %w", name, err)
+		}
+	}
+
+	if len(b.posts) == 0 {
+		return nil
+	}
+	if err := b.generate(fsys, opts, data, outputDir, home); err != nil {
+		return fmt.Errorf("blog: %w", err)
+	}
+	return nil
+}
+
+// themePages lists the page templates of a theme: its *.html.tmpl files outside the assets
+// directory. Layouts and partials that pages extend, import or show end in .html instead.
+func themePages(fsys fs.FS) ([]string, error) {
+	var pages []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && name == themeAssetsDir {
+			return fs.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(name, pageTemplateSuffix) {
+			pages = append(pages, name)
+		}
+		return nil
+	})
+	return pages, err
+}
+
+// pageOutputPath returns the path of the page rendered by a page template, relative to the
+// output directory: index.html.tmpl renders index.html and projects.html.tmpl renders
+// projects/index.html, so pages have clean URLs. 404.html.tmpl renders the 404.html page that
+// static hosts serve for missing pages.
+func pageOutputPath(name string) string {
+	page := strings.TrimSuffix(name, pageTemplateSuffix)
+	if page == "404" || path.Base(page) == "index" {
+		return filepath.FromSlash(page + ".html")
 	}
+	return filepath.FromSlash(path.Join(page, "index.html"))
+}
 
-	tmpl, err := scriggo.BuildTemplate(fs, "index.html", opts)
+// renderPage builds a page template and writes the page to the output path.
+func renderPage(fsys fs.FS, name, outputPath string, opts *scriggo.BuildOptions) error {
+	tmpl, err := scriggo.BuildTemplate(fsys, name, opts)
 	if err != nil {
 		return fmt.Errorf("failed to build template: %w", err)
 	}
+	return runPage(tmpl, outputPath)
+}
 
-	outputPath := filepath.Join(outputDir, "index.html")
+// runPage runs a built page template and writes the page to the output path.
+func runPage(tmpl *scriggo.Template, outputPath string) error {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create page directory: %w", err)
+	}
 	file, err := os.Create(outputPath)
 	if err != nil {
 		return fmt.Errorf("failed to create output file: %w", err)
@@ -105,17 +238,45 @@ func (wg *WebsiteGenerator) generateIndexPage(data *models.ResumeData, outputDir
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	logger.Logger().Info("Generated index", "outputPath", outputPath)
+	logger.Logger().Info("Generated page", "outputPath", outputPath)
 	return nil
 }
 
-// copyAssets copies static assets to the output directory
+// scriggoFS reads website templates with the format of the extension before their ".tmpl"
+// suffix, so index.html.tmpl is an HTML template.
+type scriggoFS struct {
+	fs.FS
+}
+
+// Format returns the format of the named template.
+func (scriggoFS) Format(name string) (scriggo.Format, error) {
+	switch path.Ext(strings.TrimSuffix(name, ".tmpl")) {
+	case ".html":
+		return scriggo.FormatHTML, nil
+	case ".css":
+		return scriggo.FormatCSS, nil
+	case ".js":
+		return scriggo.FormatJS, nil
+	case ".json":
+		return scriggo.FormatJSON, nil
+	case ".md":
+		return scriggo.FormatMarkdown, nil
+	}
+	return scriggo.FormatText, nil
+}
+
+// copyAssets copies the assets of the theme and the themes it extends, and then the static
+// assets, which replace theme assets with the same path, to the output directory
 func (wg *WebsiteGenerator) copyAssets(outputDir string) error {
 	assetsOutputDir := filepath.Join(outputDir, "assets")
 	if err := os.MkdirAll(assetsOutputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create assets directory: %w", err)
 	}
 
+	if err := wg.copyThemeAssets(assetsOutputDir); err != nil {
+		return fmt.Errorf("failed to copy theme assets: %w", err)
+	}
+
 	// Copy CSS, JS, images, etc.
 	excludedFiles := map[string]bool{
 		"files/.gitkeep": true,
@@ -139,6 +300,10 @@ func (wg *WebsiteGenerator) copyAssets(outputDir string) error {
 		if excludedFiles[relPath] {
 			return nil
 		}
+		// The TTF files of the fonts directory are for the PDF; pages load the WOFF2 fonts
+		if filepath.Dir(relPath) == "fonts" && strings.EqualFold(filepath.Ext(relPath), ".ttf") {
+			return nil
+		}
 
 		outputPath := filepath.Join(assetsOutputDir, relPath)
 		outputDir := filepath.Dir(outputPath)
@@ -152,6 +317,36 @@ func (wg *WebsiteGenerator) copyAssets(outputDir string) error {
 	})
 }
 
+// copyThemeAssets copies the files of the assets directory of the theme to the assets output
+// directory. Files missing from the theme are copied from the nearest theme it extends that has them.
+func (wg *WebsiteGenerator) copyThemeAssets(assetsOutputDir string) error {
+	dirs, err := themeDirs(wg.templatesDir, wg.theme)
+	if err != nil {
+		return err
+	}
+	fsys := newThemeFS(dirs)
+	if _, err := fs.Stat(fsys, themeAssetsDir); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return fs.WalkDir(fsys, themeAssetsDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		outputPath := filepath.Join(assetsOutputDir, filepath.FromSlash(strings.TrimPrefix(name, themeAssetsDir+"/")))
+		logger.Logger().Debug("Copying theme asset", "path", name, "outputPath", outputPath)
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(outputPath, content, 0644)
+	})
+}
+
 // copyFile copies a file from src to dst
 func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
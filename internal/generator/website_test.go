@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/odinnordico/odinnordico.github.io/internal/models"
 )
@@ -59,5 +60,154 @@ func TestWebsiteGenerator_Generate(t *testing.T) {
 		assert.NoError(t, err)
 		assert.FileExists(t, filepath.Join(outputDir, "index.html"))
 		assert.FileExists(t, filepath.Join(outputDir, "assets", "style.css"))
+		assert.NoFileExists(t, filepath.Join(outputDir, JSONResumeFile))
 	})
+
+	t.Run("Generate website with data exports", func(t *testing.T) {
+		wg := NewWebsiteGenerator(templatesDir, theme, assetsDir, WithDataExports(true))
+		err := wg.Generate(data, outputDir, "en", false)
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(outputDir, "index.html"))
+		assert.FileExists(t, filepath.Join(outputDir, JSONResumeFile))
+		assert.FileExists(t, filepath.Join(outputDir, DataJSONFile))
+	})
+}
+
+func TestWebsiteGenerator_GenerateExtendedTheme(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templatesDir := filepath.Join(tempDir, "templates")
+	assetsDir := filepath.Join(tempDir, "assets")
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+	write(filepath.Join(templatesDir, "default", "index.html.tmpl"), `<html><body>{{ render "partials/header.html" }}<h1>{{ Data.Basic.Name }}</h1></body></html>`)
+	write(filepath.Join(templatesDir, "default", "partials", "header.html"), `<header>Default</header>`)
+	write(filepath.Join(templatesDir, "default", "assets", "css", "theme.css"), "default")
+	write(filepath.Join(templatesDir, "default", "assets", "css", "print.css"), "default")
+	write(filepath.Join(templatesDir, "company", "theme.yaml"), "extends: default\n")
+	write(filepath.Join(templatesDir, "company", "partials", "header.html"), `<header>{{ Data.Basic.Name }} &amp; Co</header>`)
+	write(filepath.Join(templatesDir, "company", "assets", "css", "theme.css"), "company")
+	write(filepath.Join(assetsDir, "css", "print.css"), "site")
+	write(filepath.Join(assetsDir, "fonts", "Inter-Regular.ttf"), "pdf font")
+	write(filepath.Join(assetsDir, "fonts", "Inter.var.woff2"), "web font")
+
+	wg := NewWebsiteGenerator(templatesDir, "company", assetsDir)
+	data := &models.ResumeData{Basic: models.BasicData{Name: "John Doe"}}
+	assert.NoError(t, wg.Generate(data, outputDir, "en", true))
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Equal(t, "<html><body><header>John Doe &amp; Co</header><h1>John Doe</h1></body></html>", string(index))
+
+	theme, err := os.ReadFile(filepath.Join(outputDir, "assets", "css", "theme.css"))
+	assert.NoError(t, err)
+	assert.Equal(t, "company", string(theme), "theme assets resolve from the nearest theme")
+	printCSS, err := os.ReadFile(filepath.Join(outputDir, "assets", "css", "print.css"))
+	assert.NoError(t, err)
+	assert.Equal(t, "site", string(printCSS), "site assets replace theme assets")
+	assert.FileExists(t, filepath.Join(outputDir, "assets", "fonts", "Inter.var.woff2"))
+	assert.NoFileExists(t, filepath.Join(outputDir, "assets", "fonts", "Inter-Regular.ttf"), "PDF fonts are not published")
+
+	t.Run("Missing parent theme", func(t *testing.T) {
+		write(filepath.Join(templatesDir, "orphan", "theme.yaml"), "extends: missing\n")
+		err := NewWebsiteGenerator(templatesDir, "orphan", assetsDir).Generate(data, outputDir, "en", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestWebsiteGenerator_GenerateThemeOptions(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templatesDir := filepath.Join(tempDir, "templates")
+	writeTheme(t, templatesDir, "default", map[string]string{
+		"theme.yaml": `
+options:
+  show_photo: {type: bool, default: true}
+  section_order: {type: list, default: [experience, skills]}
+`,
+		"index.html.tmpl": `{% if Theme.show_photo %}<img>{% end %}` +
+			`{% for _, s := range Theme.section_order.([]string) %}<section id="{{ s }}"></section>{% end %}`,
+	})
+	data := &models.ResumeData{}
+
+	wg := NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"))
+	assert.NoError(t, wg.Generate(data, outputDir, "en", false))
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Equal(t, `<img><section id="experience"></section><section id="skills"></section>`, string(index))
+
+	wg = NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"),
+		WithWebsiteThemeOptions(map[string]any{"show_photo": "false", "section_order": "skills"}))
+	assert.NoError(t, wg.Generate(data, outputDir, "en", false))
+	index, err = os.ReadFile(filepath.Join(outputDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Equal(t, `<section id="skills"></section>`, string(index))
+
+	wg = NewWebsiteGenerator(templatesDir, "default", filepath.Join(tempDir, "assets"),
+		WithWebsiteThemeOptions(map[string]any{"layout": "wide"}))
+	assert.Error(t, wg.Generate(data, outputDir, "en", false), "options the theme does not declare")
+}
+
+func TestWebsiteGenerator_GeneratePages(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "output")
+	templatesDir := filepath.Join(tempDir, "templates")
+	writeTheme(t, templatesDir, "default", map[string]string{
+		"index.html.tmpl": `{% extends "layouts/base.html" %}{% macro Body %}{{ Data.Basic.Name }}{% end %}`,
+		"404.html.tmpl":   `{% extends "layouts/base.html" %}{% macro Body %}Not found{% end %}`,
+	})
+	writeTheme(t, templatesDir, filepath.Join("default", "layouts"), map[string]string{
+		"base.html": `<a href="{{ Home }}">{{ Lang }}</a><main>{{ Body() }}</main>`,
+	})
+	writeTheme(t, templatesDir, filepath.Join("default", "assets"), map[string]string{"page.html.tmpl": "an asset, not a page"})
+	writeTheme(t, templatesDir, "company", map[string]string{
+		"theme.yaml":         "extends: default\n",
+		"macros.html":        `{% macro Project(name string) %}<li>{{ name }}</li>{% end %}`,
+		"projects.html.tmpl": `{% extends "layouts/base.html" %}{% import "macros.html" %}{% macro Body %}<ul>{% show Project("resume") %}</ul>{% end %}`,
+	})
+	writeTheme(t, templatesDir, filepath.Join("company", "talks"), map[string]string{
+		"index.html.tmpl": `{% extends "/layouts/base.html" %}{% macro Body %}Talks{% end %}`,
+		"go.html.tmpl":    `{% extends "/layouts/base.html" %}{% macro Body %}Go{% end %}`,
+	})
+	data := &models.ResumeData{Basic: models.BasicData{Name: "John Doe"}}
+
+	wg := NewWebsiteGenerator(templatesDir, "company", filepath.Join(tempDir, "assets"))
+	require.NoError(t, wg.Generate(data, outputDir, "es", false))
+
+	pages := map[string]string{
+		"index.html":                               `<a href="/es/">es</a><main>John Doe</main>`,
+		"404.html":                                 `<a href="/es/">es</a><main>Not found</main>`,
+		filepath.Join("projects", "index.html"):    `<a href="/es/">es</a><main><ul><li>resume</li></ul></main>`,
+		filepath.Join("talks", "index.html"):       `<a href="/es/">es</a><main>Talks</main>`,
+		filepath.Join("talks", "go", "index.html"): `<a href="/es/">es</a><main>Go</main>`,
+	}
+	for name, want := range pages {
+		content, err := os.ReadFile(filepath.Join(outputDir, name))
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, want, string(content), name)
+		}
+	}
+	assert.NoDirExists(t, filepath.Join(outputDir, "assets"), "templates in the assets directory are not pages")
+}
+
+func TestPageOutputPath(t *testing.T) {
+	tests := map[string]string{
+		"index.html.tmpl":       "index.html",
+		"projects.html.tmpl":    filepath.Join("projects", "index.html"),
+		"talks/index.html.tmpl": filepath.Join("talks", "index.html"),
+		"talks/go.html.tmpl":    filepath.Join("talks", "go", "index.html"),
+		"404.html.tmpl":         "404.html",
+		"talks/404.html.tmpl":   filepath.Join("talks", "404", "index.html"),
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, pageOutputPath(name), name)
+	}
 }
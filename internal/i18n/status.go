@@ -0,0 +1,308 @@
+// Package i18n reports how completely the data/lang/<lang> overlays translate the base resume data.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/loader"
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+	"github.com/odinnordico/odinnordico.github.io/internal/vcs"
+)
+
+// FieldStatus describes the translation state of a single text field.
+type FieldStatus string
+
+const (
+	// StatusTranslated means the overlay provides a different, up-to-date value.
+	StatusTranslated FieldStatus = "translated"
+	// StatusUntranslated means the overlay omits the field or repeats the base value.
+	StatusUntranslated FieldStatus = "untranslated"
+	// StatusStale means the base value changed after the translation was last edited.
+	StatusStale FieldStatus = "stale"
+	// StatusOverlayOnly means the overlay has a value the base data does not.
+	StatusOverlayOnly FieldStatus = "overlay-only"
+)
+
+var keyedType = reflect.TypeOf((*models.Keyed)(nil)).Elem()
+
+// Field locates a text field that needs attention in a translation.
+type Field struct {
+	Section     string      `json:"section"`
+	Path        string      `json:"path"`
+	Status      FieldStatus `json:"status"`
+	BaseFile    string      `json:"base_file,omitempty"`
+	BaseLine    int         `json:"base_line,omitempty"`
+	OverlayFile string      `json:"overlay_file,omitempty"`
+	OverlayLine int         `json:"overlay_line,omitempty"`
+}
+
+// Report summarizes the translation coverage of one language.
+type Report struct {
+	Lang         string  `json:"lang"`
+	Total        int     `json:"total"`
+	Translated   int     `json:"translated"`
+	Untranslated int     `json:"untranslated"`
+	Stale        int     `json:"stale"`
+	OverlayOnly  int     `json:"overlay_only"`
+	Completeness float64 `json:"completeness"`
+	Fields       []Field `json:"fields"`
+}
+
+// Status compares every text field (tagged `i18n:"text"` in the models) of the base data in dataDir
+// with the data/lang/<lang> overlay, matching list entries by key the same way the loader merges them.
+func Status(dataDir, lang string) (*Report, error) {
+	c := &comparer{
+		report: &Report{Lang: lang, Fields: []Field{}},
+		times:  make(map[string][]time.Time),
+	}
+	overlayDir := filepath.Join(dataDir, "lang", lang)
+
+	for _, section := range loader.Sections() {
+		t, _ := loader.SectionType(section)
+		baseFile := loader.SectionFile(dataDir, section)
+		overlayFile := loader.SectionFile(overlayDir, section)
+
+		base, err := c.side(baseFile)
+		if err != nil {
+			return nil, err
+		}
+		overlay, err := c.side(overlayFile)
+		if err != nil {
+			return nil, err
+		}
+
+		c.section = section
+		c.compare(base, overlay, t, "")
+	}
+
+	r := c.report
+	if r.Total > 0 {
+		r.Completeness = float64(r.Translated) / float64(r.Total) * 100
+	} else {
+		r.Completeness = 100
+	}
+	return r, nil
+}
+
+// node is a YAML node together with the file it was read from.
+type node struct {
+	file string
+	*yaml.Node
+}
+
+// comparer walks the base and overlay node trees of a section in parallel.
+type comparer struct {
+	section string
+	report  *Report
+	times   map[string][]time.Time
+}
+
+// side parses a section file, returning a nil node if the file does not exist or is empty.
+func (c *comparer) side(path string) (*node, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal YAML from %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return &node{file: path, Node: doc.Content[0]}, nil
+}
+
+func (c *comparer) compare(base, overlay *node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if !field.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			baseValue, overlayValue := lookup(base, name), lookup(overlay, name)
+			if field.Tag.Get("i18n") == "text" {
+				c.classify(baseValue, overlayValue, joinPath(path, name))
+			} else {
+				c.compare(baseValue, overlayValue, field.Type, joinPath(path, name))
+			}
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Struct {
+			c.compareSlice(base, overlay, t.Elem(), path)
+		}
+	}
+}
+
+// compareSlice pairs list entries the way the loader merges them: by merge key, falling back to
+// their position.
+func (c *comparer) compareSlice(base, overlay *node, elem reflect.Type, path string) {
+	baseItems, overlayItems := items(base), items(overlay)
+	keys := func(list []*node) []string {
+		k := make([]string, len(list))
+		for i, item := range list {
+			k[i] = mergeKey(item, elem)
+		}
+		return k
+	}
+
+	matched := make(map[int]*node, len(overlayItems))
+	total := len(baseItems)
+	for i, target := range loader.MatchKeyed(keys(baseItems), keys(overlayItems)) {
+		matched[target] = overlayItems[i]
+		total = max(total, target+1)
+	}
+
+	for i, item := range baseItems {
+		c.compare(item, matched[i], elem, fmt.Sprintf("%s[%d]", path, i))
+	}
+	for i := len(baseItems); i < total; i++ {
+		c.compare(nil, matched[i], elem, fmt.Sprintf("%s[+%d]", path, i-len(baseItems)))
+	}
+}
+
+// classify records the translation state of a text field.
+func (c *comparer) classify(base, overlay *node, path string) {
+	baseText, overlayText := text(base), text(overlay)
+
+	f := Field{Section: c.section, Path: path}
+	if base != nil {
+		f.BaseFile, f.BaseLine = base.file, base.Line
+	}
+	if overlay != nil {
+		f.OverlayFile, f.OverlayLine = overlay.file, overlay.Line
+	}
+
+	switch {
+	case baseText == "" && overlayText == "":
+		return
+	case baseText == "":
+		f.Status = StatusOverlayOnly
+		c.report.OverlayOnly++
+	case overlayText == "" || overlayText == baseText:
+		f.Status = StatusUntranslated
+		c.report.Total++
+		c.report.Untranslated++
+	case c.lastChange(base).After(c.lastChange(overlay)):
+		f.Status = StatusStale
+		c.report.Total++
+		c.report.Stale++
+	default:
+		c.report.Total++
+		c.report.Translated++
+		return
+	}
+
+	c.report.Fields = append(c.report.Fields, f)
+}
+
+// lastChange returns the latest change time of the lines spanned by n.
+func (c *comparer) lastChange(n *node) time.Time {
+	times, ok := c.times[n.file]
+	if !ok {
+		var err error
+		if times, err = vcs.LineTimes(n.file); err != nil {
+			times = nil
+		}
+		c.times[n.file] = times
+	}
+
+	var latest time.Time
+	first, last := lineSpan(n.Node)
+	for line := first; line <= last && line < len(times); line++ {
+		if times[line].After(latest) {
+			latest = times[line]
+		}
+	}
+	return latest
+}
+
+// lookup returns the value of key in a mapping node, or nil.
+func lookup(n *node, key string) *node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return &node{file: n.file, Node: n.Content[i+1]}
+		}
+	}
+	return nil
+}
+
+// items returns the entries of a sequence node.
+func items(n *node) []*node {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	result := make([]*node, len(n.Content))
+	for i, item := range n.Content {
+		result[i] = &node{file: n.file, Node: item}
+	}
+	return result
+}
+
+// mergeKey decodes a list entry to compute its models.Keyed merge key.
+func mergeKey(n *node, elem reflect.Type) string {
+	if !elem.Implements(keyedType) {
+		return ""
+	}
+	value := reflect.New(elem)
+	if err := n.Decode(value.Interface()); err != nil {
+		return ""
+	}
+	return value.Elem().Interface().(models.Keyed).MergeKey()
+}
+
+// text returns the trimmed text of a scalar, or the joined text of a list of scalars.
+func text(n *node) string {
+	if n == nil || n.Tag == "!!null" {
+		return ""
+	}
+	if n.Kind == yaml.SequenceNode {
+		var parts []string
+		for _, item := range n.Content {
+			parts = append(parts, strings.TrimSpace(item.Value))
+		}
+		return strings.Join(parts, "\n")
+	}
+	return strings.TrimSpace(n.Value)
+}
+
+// lineSpan returns the first and last source lines occupied by a node, including block scalar bodies.
+func lineSpan(n *yaml.Node) (int, int) {
+	first, last := n.Line, n.Line
+	if n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		last += strings.Count(strings.TrimRight(n.Value, "\n"), "\n") + 1
+	}
+	for _, child := range n.Content {
+		_, childLast := lineSpan(child)
+		if childLast > last {
+			last = childLast
+		}
+	}
+	return first, last
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_i18n_status")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	langDir := filepath.Join(tempDir, "lang", "es")
+	if err := os.MkdirAll(langDir, 0755); err != nil {
+		t.Fatalf("Failed to create lang dir: %v", err)
+	}
+
+	writeFile := func(path, content string, modTime time.Time) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", path, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set file time %s: %v", path, err)
+		}
+	}
+
+	older := time.Now().Add(-48 * time.Hour)
+	newer := time.Now().Add(-time.Hour)
+
+	writeFile(filepath.Join(tempDir, "basic.yml"), "name: John Doe\nphrase: Keep going\nsummary: Builds things\n", older)
+	writeFile(filepath.Join(langDir, "basic.yml"), "phrase: Sigue adelante\n", newer)
+
+	writeFile(filepath.Join(tempDir, "professional.yml"), `title: Engineer
+jobs:
+  - position: Developer
+    start_date: 2020-01-15
+    company:
+      name: Acme
+    job_description: Builds services
+  - position: Lead
+    start_date: 2022-03-01
+    company:
+      name: Beta
+    job_description: Leads teams
+`, newer)
+	writeFile(filepath.Join(langDir, "professional.yml"), `title: Ingeniero
+jobs:
+  - start_date: 2022-03-01
+    company:
+      name: Beta
+    position: Lead
+    job_description: Lidera equipos
+  - start_date: 2023-01-01
+    company:
+      name: Gamma
+    position: Consultor
+`, older)
+
+	report, err := Status(tempDir, "es")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "es", report.Lang)
+	assert.Equal(t, 7, report.Total)
+	assert.Equal(t, 1, report.Translated)
+	assert.Equal(t, 2, report.Stale)
+	assert.Equal(t, 4, report.Untranslated)
+	assert.Equal(t, 1, report.OverlayOnly)
+	assert.InDelta(t, 14.29, report.Completeness, 0.01)
+
+	statuses := make(map[string]FieldStatus)
+	for _, f := range report.Fields {
+		statuses[f.Section+"."+f.Path] = f.Status
+	}
+	assert.Equal(t, map[string]FieldStatus{
+		"professional.title":                   StatusStale,
+		"basic.summary":                        StatusUntranslated,
+		"professional.jobs[0].position":        StatusUntranslated,
+		"professional.jobs[0].job_description": StatusUntranslated,
+		"professional.jobs[1].position":        StatusUntranslated,
+		"professional.jobs[1].job_description": StatusStale,
+		"professional.jobs[+0].position":       StatusOverlayOnly,
+	}, statuses)
+
+	t.Run("Language without overlay", func(t *testing.T) {
+		report, err := Status(tempDir, "fr")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Translated)
+		assert.Equal(t, report.Total, report.Untranslated)
+		assert.Equal(t, 0.0, report.Completeness)
+	})
+}
@@ -0,0 +1,133 @@
+package jsonresume
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+// SchemaURL is the JSON Schema that exported resumes declare in their $schema field.
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// FromResumeData maps the application's resume data model onto a JSON Resume.
+func FromResumeData(data *models.ResumeData) *Resume {
+	r := &Resume{
+		Schema: SchemaURL,
+		Basics: Basics{
+			Name:    data.Basic.Name,
+			Label:   data.Professional.Title,
+			URL:     data.Basic.Website,
+			Summary: data.Basic.Summary,
+		},
+	}
+
+	if data.Basic.Location != "" {
+		r.Basics.Location = &Location{City: data.Basic.Location}
+	}
+
+	for _, s := range data.Social {
+		switch {
+		case strings.HasPrefix(s.URL, "mailto:"):
+			r.Basics.Email = strings.TrimPrefix(s.URL, "mailto:")
+		case strings.HasPrefix(s.URL, "tel:"):
+			r.Basics.Phone = strings.TrimPrefix(s.URL, "tel:")
+		default:
+			r.Basics.Profiles = append(r.Basics.Profiles, Profile{
+				Network:  s.Name,
+				Username: lastPathSegment(s.URL),
+				URL:      s.URL,
+			})
+		}
+	}
+
+	for _, job := range data.Professional.Jobs {
+		summary, highlights := splitDescription(job.JobDescription)
+		r.Work = append(r.Work, Work{
+			Name:       job.Company.Name,
+			Position:   job.Position,
+			URL:        job.Company.URL,
+			StartDate:  formatDate(job.StartDate),
+			EndDate:    formatEndDate(job.EndDate),
+			Summary:    summary,
+			Highlights: highlights,
+		})
+	}
+
+	for _, edu := range data.Education {
+		url := edu.Provider.URL
+		if url == "" {
+			url = edu.URL
+		}
+		r.Education = append(r.Education, Education{
+			Institution: edu.Provider.Name,
+			URL:         url,
+			Area:        edu.Title,
+			StudyType:   edu.Level,
+			EndDate:     formatDate(edu.Date),
+		})
+	}
+
+	for _, cert := range data.Certificates {
+		url := cert.CertificateURL
+		if url == "" {
+			url = cert.URL
+		}
+		r.Certificates = append(r.Certificates, Certificate{
+			Name:   cert.Name,
+			Date:   formatDate(cert.Date),
+			Issuer: cert.Provider.Name,
+			URL:    url,
+		})
+	}
+
+	for _, skill := range data.Skills {
+		s := Skill{Name: skill.Name, Keywords: skill.Tags}
+		if skill.Level > 0 {
+			s.Level = strconv.Itoa(skill.Level)
+		}
+		r.Skills = append(r.Skills, s)
+	}
+
+	return r
+}
+
+// splitDescription separates the free text of a description from its "- item" lines.
+func splitDescription(description string) (string, []string) {
+	var text, items []string
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			items = append(items, strings.TrimSpace(line[2:]))
+		default:
+			text = append(text, line)
+		}
+	}
+	return strings.Join(text, "\n"), items
+}
+
+// formatDate formats t as an ISO 8601 date, or returns "" for the zero time.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayouts[0])
+}
+
+// formatEndDate formats an optional end date; nil means the entry is ongoing.
+func formatEndDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatDate(*t)
+}
+
+// lastPathSegment returns the last non-empty path segment of a URL, typically the username of a profile.
+func lastPathSegment(url string) string {
+	parts := strings.Split(strings.TrimRight(url, "/"), "/")
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,58 @@
+package jsonresume
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestFromResumeData(t *testing.T) {
+	end := time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC)
+	data := &models.ResumeData{
+		Basic: models.BasicData{Name: "John Doe", Location: "Berlin", Website: "https://john.dev"},
+		Professional: models.ProfessionalData{
+			Title: "Engineer",
+			Jobs: []models.Job{{
+				Position:       "Developer",
+				StartDate:      time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:        &end,
+				JobDescription: "Backend team\n- Shipped X\n- Led Y\n",
+				Company:        models.Entity{Name: "Acme", URL: "https://acme.com"},
+			}},
+		},
+		Skills: []models.Skill{{Name: "Go", Level: 9, Tags: []string{"backend"}}},
+		Social: []models.Entity{
+			{Name: "GitHub", URL: "https://github.com/johndoe"},
+			{Name: "Email", URL: "mailto:john@example.com"},
+		},
+	}
+
+	r := FromResumeData(data)
+
+	assert.Equal(t, SchemaURL, r.Schema)
+	assert.Equal(t, "John Doe", r.Basics.Name)
+	assert.Equal(t, "Engineer", r.Basics.Label)
+	assert.Equal(t, "john@example.com", r.Basics.Email)
+	assert.Equal(t, []Profile{{Network: "GitHub", Username: "johndoe", URL: "https://github.com/johndoe"}}, r.Basics.Profiles)
+
+	if assert.Len(t, r.Work, 1) {
+		assert.Equal(t, "2019-03-01", r.Work[0].StartDate)
+		assert.Equal(t, "2021-06-30", r.Work[0].EndDate)
+		assert.Equal(t, "Backend team", r.Work[0].Summary)
+		assert.Equal(t, []string{"Shipped X", "Led Y"}, r.Work[0].Highlights)
+	}
+
+	assert.Equal(t, []Skill{{Name: "Go", Level: "9", Keywords: []string{"backend"}}}, r.Skills)
+
+	t.Run("Round trip", func(t *testing.T) {
+		back, err := ToResumeData(r)
+		assert.NoError(t, err)
+		assert.Equal(t, data.Basic.Name, back.Basic.Name)
+		assert.Equal(t, data.Basic.Location, back.Basic.Location)
+		assert.Equal(t, data.Professional.Jobs, back.Professional.Jobs)
+		assert.Equal(t, data.Skills, back.Skills)
+	})
+}
@@ -0,0 +1,227 @@
+package jsonresume
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+// skillLevels maps the textual skill levels commonly used in JSON Resume files onto the 1-10 scale of models.Skill.
+var skillLevels = map[string]int{
+	"novice":       2,
+	"beginner":     3,
+	"basic":        3,
+	"elementary":   4,
+	"intermediate": 6,
+	"proficient":   7,
+	"advanced":     8,
+	"fluent":       8,
+	"expert":       9,
+	"master":       10,
+	"native":       10,
+}
+
+// profileURLs maps the networks of JSON Resume profiles onto the URL of a profile without the
+// username, for profiles that only give the username.
+var profileURLs = map[string]string{
+	"facebook":      "https://www.facebook.com/",
+	"github":        "https://github.com/",
+	"gitlab":        "https://gitlab.com/",
+	"instagram":     "https://www.instagram.com/",
+	"linkedin":      "https://www.linkedin.com/in/",
+	"mastodon":      "https://mastodon.social/@",
+	"medium":        "https://medium.com/@",
+	"stackoverflow": "https://stackoverflow.com/users/",
+	"telegram":      "https://t.me/",
+	"twitter":       "https://x.com/",
+	"x":             "https://x.com/",
+	"youtube":       "https://www.youtube.com/@",
+}
+
+// ToResumeData maps a JSON Resume onto the application's resume data model.
+func ToResumeData(r *Resume) (*models.ResumeData, error) {
+	if r == nil {
+		return nil, fmt.Errorf("resume cannot be nil")
+	}
+
+	data := &models.ResumeData{
+		Basic: models.BasicData{
+			Name:     r.Basics.Name,
+			Location: formatLocation(r.Basics.Location),
+			Summary:  r.Basics.Summary,
+			Website:  r.Basics.URL,
+		},
+		Professional: models.ProfessionalData{
+			Title: r.Basics.Label,
+		},
+		Social: importSocial(r.Basics),
+	}
+
+	for i, w := range r.Work {
+		job, err := importWork(w)
+		if err != nil {
+			return nil, fmt.Errorf("work[%d]: %w", i, err)
+		}
+		data.Professional.Jobs = append(data.Professional.Jobs, job)
+	}
+
+	for i, e := range r.Education {
+		edu, err := importEducation(e)
+		if err != nil {
+			return nil, fmt.Errorf("education[%d]: %w", i, err)
+		}
+		data.Education = append(data.Education, edu)
+	}
+
+	for i, c := range r.Certificates {
+		date, err := parseDate(c.Date)
+		if err != nil {
+			return nil, fmt.Errorf("certificates[%d]: %w", i, err)
+		}
+		data.Certificates = append(data.Certificates, models.Certificate{
+			Name:           c.Name,
+			Date:           date,
+			CertificateURL: c.URL,
+			Provider:       models.Entity{Name: c.Issuer},
+		})
+	}
+
+	for _, s := range r.Skills {
+		data.Skills = append(data.Skills, models.Skill{
+			Name:  s.Name,
+			Level: skillLevel(s.Level),
+			Tags:  s.Keywords,
+		})
+	}
+
+	return data, nil
+}
+
+// importSocial converts the contact details and profiles of the basics section into social entities.
+// Profiles without a URL get one from their network and username, and are left out when the
+// network is unknown, as a social entity needs a URL.
+func importSocial(b Basics) []models.Entity {
+	var social []models.Entity
+	for _, p := range b.Profiles {
+		url := p.URL
+		if url == "" {
+			prefix, ok := profileURLs[strings.ToLower(p.Network)]
+			if !ok || p.Username == "" {
+				continue
+			}
+			url = prefix + p.Username
+		}
+		social = append(social, models.Entity{
+			Name: p.Network,
+			URL:  url,
+			Logo: models.Logo{Library: "brands", Image: strings.ToLower(p.Network)},
+		})
+	}
+	if b.Email != "" {
+		social = append(social, models.Entity{
+			Name: "Email",
+			URL:  "mailto:" + b.Email,
+			Logo: models.Logo{Library: "solid", Image: "envelope"},
+		})
+	}
+	if b.Phone != "" {
+		social = append(social, models.Entity{
+			Name: "Phone",
+			URL:  "tel:" + strings.ReplaceAll(b.Phone, " ", ""),
+			// The envelope is the only contact icon in assets/media
+			Logo: models.Logo{Library: "solid", Image: "envelope"},
+		})
+	}
+	return social
+}
+
+// importWork converts a work entry into a job, turning highlights into a "- item" description list.
+func importWork(w Work) (models.Job, error) {
+	start, err := parseDate(w.StartDate)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	job := models.Job{
+		Position:       w.Position,
+		StartDate:      start,
+		JobDescription: joinDescription(w.Summary, w.Highlights),
+		Company:        models.Entity{Name: w.Name, URL: w.URL},
+	}
+
+	if w.EndDate != "" {
+		end, err := parseDate(w.EndDate)
+		if err != nil {
+			return models.Job{}, err
+		}
+		job.EndDate = &end
+	}
+
+	return job, nil
+}
+
+// importEducation converts an education entry, dating it by its end date when one is known.
+func importEducation(e Education) (models.Education, error) {
+	dateValue := e.EndDate
+	if dateValue == "" {
+		dateValue = e.StartDate
+	}
+	date, err := parseDate(dateValue)
+	if err != nil {
+		return models.Education{}, err
+	}
+
+	title := e.Area
+	if title == "" {
+		title = e.StudyType
+	}
+
+	return models.Education{
+		Title:       title,
+		Date:        date,
+		Level:       e.StudyType,
+		Provider:    models.Entity{Name: e.Institution, URL: e.URL},
+		Description: joinDescription(e.Score, e.Courses),
+	}, nil
+}
+
+// joinDescription builds a multi-line description from a summary followed by "- item" lines.
+func joinDescription(summary string, items []string) string {
+	var lines []string
+	if summary = strings.TrimSpace(summary); summary != "" {
+		lines = append(lines, summary)
+	}
+	for _, item := range items {
+		lines = append(lines, "- "+strings.TrimSpace(item))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// formatLocation joins the populated parts of a location into a single line.
+func formatLocation(l *Location) string {
+	if l == nil {
+		return ""
+	}
+	var parts []string
+	for _, part := range []string{l.City, l.Region, l.CountryCode} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// skillLevel converts a textual or numeric JSON Resume skill level onto the 1-10 scale.
+// Unknown levels yield 0, which leaves the level unset.
+func skillLevel(level string) int {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if n, err := strconv.Atoi(level); err == nil {
+		return min(max(n, 1), 10)
+	}
+	return skillLevels[level]
+}
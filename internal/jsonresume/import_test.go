@@ -0,0 +1,140 @@
+package jsonresume
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+const sampleResume = `{
+  "basics": {
+    "name": "Jane Roe",
+    "label": "Software Engineer",
+    "email": "jane@example.com",
+    "phone": "+1 555 1234",
+    "url": "https://jane.dev",
+    "summary": "Builds things.",
+    "location": {"city": "Berlin", "countryCode": "DE"},
+    "profiles": [{"network": "GitHub", "username": "jane", "url": "https://github.com/jane"}]
+  },
+  "work": [
+    {"name": "Acme", "position": "Developer", "url": "https://acme.com", "startDate": "2019-03", "endDate": "2021-06-30", "summary": "Backend team", "highlights": ["Shipped X"]},
+    {"name": "Now Inc", "position": "Lead", "startDate": "2021-07-01"}
+  ],
+  "education": [{"institution": "MIT", "area": "Computer Science", "studyType": "Bachelor", "endDate": "2015"}],
+  "certificates": [{"name": "CKAD", "date": "2022-01-15", "issuer": "CNCF", "url": "https://cncf.io/c/1"}],
+  "skills": [{"name": "Go", "level": "Master", "keywords": ["backend"]}, {"name": "Rust", "level": "7"}]
+}`
+
+func TestLoad(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_jsonresume")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "resume.json")
+	if err := os.WriteFile(path, []byte(sampleResume), 0644); err != nil {
+		t.Fatalf("Failed to create resume file: %v", err)
+	}
+
+	t.Run("Valid file", func(t *testing.T) {
+		r, err := Load(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane Roe", r.Basics.Name)
+		assert.Len(t, r.Work, 2)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := Load(filepath.Join(tempDir, "missing.json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestToResumeData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_jsonresume_import")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "resume.json")
+	if err := os.WriteFile(path, []byte(sampleResume), 0644); err != nil {
+		t.Fatalf("Failed to create resume file: %v", err)
+	}
+	r, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load resume: %v", err)
+	}
+
+	data, err := ToResumeData(r)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Jane Roe", data.Basic.Name)
+	assert.Equal(t, "Berlin, DE", data.Basic.Location)
+	assert.Equal(t, "https://jane.dev", data.Basic.Website)
+	assert.Equal(t, "Software Engineer", data.Professional.Title)
+
+	if assert.Len(t, data.Professional.Jobs, 2) {
+		job := data.Professional.Jobs[0]
+		assert.Equal(t, "Developer", job.Position)
+		assert.Equal(t, "Acme", job.Company.Name)
+		assert.Equal(t, time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC), job.StartDate)
+		assert.Equal(t, time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC), *job.EndDate)
+		assert.Equal(t, "Backend team\n- Shipped X\n", job.JobDescription)
+		assert.Nil(t, data.Professional.Jobs[1].EndDate)
+	}
+
+	if assert.Len(t, data.Education, 1) {
+		assert.Equal(t, "Computer Science", data.Education[0].Title)
+		assert.Equal(t, "Bachelor", data.Education[0].Level)
+		assert.Equal(t, 2015, data.Education[0].Date.Year())
+	}
+
+	if assert.Len(t, data.Certificates, 1) {
+		assert.Equal(t, "CNCF", data.Certificates[0].Provider.Name)
+		assert.Equal(t, "https://cncf.io/c/1", data.Certificates[0].CertificateURL)
+	}
+
+	if assert.Len(t, data.Skills, 2) {
+		assert.Equal(t, 10, data.Skills[0].Level)
+		assert.Equal(t, []string{"backend"}, data.Skills[0].Tags)
+		assert.Equal(t, 7, data.Skills[1].Level)
+	}
+
+	if assert.Len(t, data.Social, 3) {
+		assert.Equal(t, "GitHub", data.Social[0].Name)
+		assert.Equal(t, "github", data.Social[0].Logo.Image)
+		assert.Equal(t, "mailto:jane@example.com", data.Social[1].URL)
+		assert.Equal(t, "tel:+15551234", data.Social[2].URL)
+		assert.FileExists(t, filepath.Join("..", "..", "assets", "media", data.Social[2].Logo.Library, data.Social[2].Logo.Image+".png"))
+	}
+
+	t.Run("Profiles without URL", func(t *testing.T) {
+		data, err := ToResumeData(&Resume{Basics: Basics{Profiles: []Profile{
+			{Network: "LinkedIn", Username: "jane"},
+			{Network: "MySpace", Username: "jane"},
+		}}})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Entity{{
+			Name: "LinkedIn",
+			URL:  "https://www.linkedin.com/in/jane",
+			Logo: models.Logo{Library: "brands", Image: "linkedin"},
+		}}, data.Social, "profiles of unknown networks are left out")
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		_, err := ToResumeData(&Resume{Work: []Work{{StartDate: "last year"}}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Nil resume", func(t *testing.T) {
+		_, err := ToResumeData(nil)
+		assert.Error(t, err)
+	})
+}
@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 
-	"dario.cat/mergo"
 	"gopkg.in/yaml.v3"
 
 	"github.com/odinnordico/odinnordico.github.io/internal/logger"
@@ -25,6 +26,37 @@ var (
 	}
 )
 
+// Sections returns the names of the supported data sections (basic, professional, ...) in a stable order.
+func Sections() []string {
+	names := make([]string, 0, len(supportedFiles))
+	for name := range supportedFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SectionType returns the Go type the data file of a section decodes into.
+func SectionType(section string) (reflect.Type, bool) {
+	targetFn, ok := supportedFiles[section]
+	if !ok {
+		return nil, false
+	}
+	return reflect.TypeOf(targetFn(&models.ResumeData{})).Elem(), true
+}
+
+// SectionFile returns the path of the data file for a section in dir, trying each
+// supported extension in turn, or "" if there is none.
+func SectionFile(dir, section string) string {
+	for _, ext := range extensions {
+		path := filepath.Join(dir, fmt.Sprintf("%s.%s", section, ext))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
 func LoadResumeData(dataDir, lang string) (*models.ResumeData, error) {
 	resumeData := &models.ResumeData{}
 	for _, ext := range extensions {
@@ -68,8 +100,8 @@ func loadYAMLData(dataDir, file, lang string, target *models.ResumeData, targetF
 			return err
 		}
 
-		// Deep merge language data onto the base data (target)
-		if err := mergo.Merge(targetResume, targetLangResume, mergo.WithOverride); err != nil {
+		// Deep merge language data onto the base data (target), matching list entries by key
+		if err := mergeOverlay(targetResume, targetLangResume); err != nil {
 			logger.Logger().Error("failed to merge language data", "language", lang, "file", file, "error", err)
 			return err
 		}
@@ -39,6 +39,38 @@ func TestLoadResumeData(t *testing.T) {
 		assert.Equal(t, "Juan Perez", data.Basic.Name)
 	})
 
+	t.Run("Load partial language overlay", func(t *testing.T) {
+		createYAMLFile(t, tempDir, "professional.yaml", `title: Software Engineer
+jobs:
+  - position: Developer
+    start_date: 2020-01-15
+    company:
+      name: Acme
+      url: https://acme.com
+    job_description: Builds things
+  - position: Lead
+    start_date: 2022-03-01
+    company:
+      name: Beta
+    job_description: Leads teams
+`)
+		createYAMLFile(t, langDir, "professional.yaml", `jobs:
+  - start_date: 2022-03-01
+    company:
+      name: Beta
+    job_description: Lidera equipos
+`)
+		defer os.Remove(filepath.Join(langDir, "professional.yaml"))
+
+		data, err := LoadResumeData(tempDir, "es")
+		assert.NoError(t, err)
+		if assert.Len(t, data.Professional.Jobs, 2) {
+			assert.Equal(t, "Builds things", data.Professional.Jobs[0].JobDescription)
+			assert.Equal(t, "Lead", data.Professional.Jobs[1].Position)
+			assert.Equal(t, "Lidera equipos", data.Professional.Jobs[1].JobDescription)
+		}
+	})
+
 	t.Run("Load non-existent language", func(t *testing.T) {
 		data, err := LoadResumeData(tempDir, "fr")
 		assert.NoError(t, err)
@@ -0,0 +1,105 @@
+package loader
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+var keyedType = reflect.TypeOf((*models.Keyed)(nil)).Elem()
+
+// mergeOverlay deep merges a language overlay onto the base data. Non-zero overlay values
+// override the base, structs merge field by field, and lists of models.Keyed entries merge
+// entry by entry on their key, so an overlay only needs to contain the fields it translates.
+// Both arguments must be pointers to the same type.
+func mergeOverlay(dst, src any) error {
+	dstValue, srcValue := reflect.ValueOf(dst), reflect.ValueOf(src)
+	if dstValue.Kind() != reflect.Ptr || srcValue.Type() != dstValue.Type() {
+		return fmt.Errorf("cannot merge %T onto %T", src, dst)
+	}
+
+	mergeValue(dstValue.Elem(), srcValue.Elem())
+	return nil
+}
+
+func mergeValue(dst, src reflect.Value) {
+	switch {
+	case src.IsZero():
+		return
+	case dst.Type() == timeType:
+		dst.Set(src)
+	case dst.Kind() == reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			if dst.Type().Field(i).IsExported() {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case dst.Kind() == reflect.Slice && dst.Type().Elem().Implements(keyedType):
+		mergeKeyedSlice(dst, src)
+	default:
+		dst.Set(src)
+	}
+}
+
+// mergeKeyedSlice merges every overlay entry onto the base entry it matches, and appends the
+// entries that match none.
+func mergeKeyedSlice(dst, src reflect.Value) {
+	keys := func(v reflect.Value) []string {
+		k := make([]string, v.Len())
+		for i := range k {
+			k[i] = mergeKey(v.Index(i))
+		}
+		return k
+	}
+
+	merged := reflect.MakeSlice(dst.Type(), dst.Len(), dst.Len()+src.Len())
+	reflect.Copy(merged, dst)
+	for i, target := range MatchKeyed(keys(dst), keys(src)) {
+		if target < merged.Len() {
+			mergeValue(merged.Index(target), src.Index(i))
+		} else {
+			merged = reflect.Append(merged, src.Index(i))
+		}
+	}
+
+	dst.Set(merged)
+}
+
+// MatchKeyed pairs the entries of an overlay list with the entries of a base list, given their
+// merge keys, the way the loader merges them. It returns, for each overlay entry, the index in
+// the merged list of the entry it merges onto. Entries match the base entry with the same key;
+// entries without a key fall back to the base entry at their position, and the others are
+// appended after the base entries, at len(baseKeys) and up. A later entry with the key of an
+// appended entry merges onto it.
+func MatchKeyed(baseKeys, overlayKeys []string) []int {
+	index := make(map[string]int, len(baseKeys))
+	for i, key := range baseKeys {
+		if key != "" {
+			index[key] = i
+		}
+	}
+
+	targets := make([]int, len(overlayKeys))
+	appended := len(baseKeys)
+	for i, key := range overlayKeys {
+		if j, ok := index[key]; ok && key != "" {
+			targets[i] = j
+			continue
+		}
+		if key == "" && i < len(baseKeys) {
+			targets[i] = i
+			continue
+		}
+		targets[i] = appended
+		if key != "" {
+			index[key] = appended
+		}
+		appended++
+	}
+	return targets
+}
+
+func mergeKey(v reflect.Value) string {
+	return v.Interface().(models.Keyed).MergeKey()
+}
@@ -0,0 +1,116 @@
+package loader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestMergeOverlay(t *testing.T) {
+	start := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	base := func() *models.ProfessionalData {
+		return &models.ProfessionalData{
+			Title: "Engineer",
+			Jobs: []models.Job{
+				{Position: "Lead", StartDate: end, Company: models.Entity{Name: "Beta", URL: "https://beta.com"}, JobDescription: "Leads"},
+				{Position: "Developer", StartDate: start, EndDate: &end, Company: models.Entity{Name: "Acme", URL: "https://acme.com"}, JobDescription: "Builds"},
+			},
+		}
+	}
+
+	t.Run("Merge by natural key", func(t *testing.T) {
+		dst := base()
+		src := &models.ProfessionalData{
+			Jobs: []models.Job{
+				{StartDate: start, Company: models.Entity{Name: "Acme"}, JobDescription: "Construye"},
+			},
+		}
+
+		assert.NoError(t, mergeOverlay(dst, src))
+		assert.Equal(t, "Engineer", dst.Title)
+		if assert.Len(t, dst.Jobs, 2) {
+			assert.Equal(t, "Leads", dst.Jobs[0].JobDescription)
+			assert.Equal(t, "Construye", dst.Jobs[1].JobDescription)
+			assert.Equal(t, "Developer", dst.Jobs[1].Position)
+			assert.Equal(t, "https://acme.com", dst.Jobs[1].Company.URL)
+			assert.Equal(t, &end, dst.Jobs[1].EndDate)
+		}
+	})
+
+	t.Run("Merge by explicit id", func(t *testing.T) {
+		dst := base()
+		dst.Jobs[0].ID = "beta"
+		src := &models.ProfessionalData{
+			Jobs: []models.Job{{ID: "beta", Position: "Líder"}},
+		}
+
+		assert.NoError(t, mergeOverlay(dst, src))
+		if assert.Len(t, dst.Jobs, 2) {
+			assert.Equal(t, "Líder", dst.Jobs[0].Position)
+			assert.Equal(t, "Leads", dst.Jobs[0].JobDescription)
+		}
+	})
+
+	t.Run("Unkeyed entries merge by position", func(t *testing.T) {
+		dst := base()
+		src := &models.ProfessionalData{
+			Jobs: []models.Job{{Position: "Líder"}},
+		}
+
+		assert.NoError(t, mergeOverlay(dst, src))
+		if assert.Len(t, dst.Jobs, 2) {
+			assert.Equal(t, "Líder", dst.Jobs[0].Position)
+			assert.Equal(t, "Developer", dst.Jobs[1].Position)
+		}
+	})
+
+	t.Run("New keys are appended", func(t *testing.T) {
+		dst := base()
+		src := &models.ProfessionalData{
+			Jobs: []models.Job{{Position: "Intern", StartDate: start, Company: models.Entity{Name: "Gamma"}}},
+		}
+
+		assert.NoError(t, mergeOverlay(dst, src))
+		if assert.Len(t, dst.Jobs, 3) {
+			assert.Equal(t, "Intern", dst.Jobs[2].Position)
+		}
+	})
+
+	t.Run("Plain lists are replaced", func(t *testing.T) {
+		dst := &[]models.Skill{{Name: "Go", Level: 9, Tags: []string{"backend", "cloud"}}}
+		src := &[]models.Skill{{Name: "Go", Tags: []string{"servidor"}}}
+
+		assert.NoError(t, mergeOverlay(dst, src))
+		assert.Equal(t, []models.Skill{{Name: "Go", Level: 9, Tags: []string{"servidor"}}}, *dst)
+	})
+
+	t.Run("Mismatched types", func(t *testing.T) {
+		assert.Error(t, mergeOverlay(&models.BasicData{}, &models.ProfessionalData{}))
+	})
+}
+
+func TestMatchKeyed(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseKeys    []string
+		overlayKeys []string
+		want        []int
+	}{
+		{name: "By key", baseKeys: []string{"a", "b"}, overlayKeys: []string{"b", "a"}, want: []int{1, 0}},
+		{name: "By position without key", baseKeys: []string{"a", "b"}, overlayKeys: []string{"", ""}, want: []int{0, 1}},
+		{name: "Unknown keys are appended", baseKeys: []string{"a"}, overlayKeys: []string{"c", "a", "d"}, want: []int{1, 0, 2}},
+		{name: "Entries past the base are appended", baseKeys: []string{"a"}, overlayKeys: []string{"", ""}, want: []int{0, 1}},
+		{name: "Repeated appended key", baseKeys: nil, overlayKeys: []string{"c", "c"}, want: []int{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchKeyed(tt.baseKeys, tt.overlayKeys))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+	"github.com/odinnordico/odinnordico.github.io/internal/utils"
+)
+
+const (
+	// postsDir is the directory of the blog posts in the data directory and in each language.
+	postsDir = "posts"
+	// frontMatterDelimiter opens and closes the YAML front matter of a post.
+	frontMatterDelimiter = "---"
+)
+
+// LoadPosts reads the blog posts of a language, drafts included, newest first: the Markdown
+// files of data/posts, replaced by the files with the same name in data/lang/<lang>/posts, and
+// the posts written only in that language.
+func LoadPosts(dataDir, lang string) ([]models.Post, error) {
+	dirs := []string{filepath.Join(dataDir, postsDir)}
+	if lang != utils.DefaultLang && lang != "" {
+		dirs = append(dirs, filepath.Join(dataDir, "lang", lang, postsDir))
+	}
+
+	bySlug := make(map[string]models.Post)
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+		if err != nil {
+			return nil, fmt.Errorf("find posts: %w", err)
+		}
+		for _, file := range files {
+			post, err := loadPost(file)
+			if err != nil {
+				return nil, err
+			}
+			bySlug[post.Slug] = post
+		}
+	}
+
+	posts := make([]models.Post, 0, len(bySlug))
+	for _, post := range bySlug {
+		posts = append(posts, post)
+	}
+	slices.SortFunc(posts, func(a, b models.Post) int {
+		if c := b.Date.Compare(a.Date); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Slug, b.Slug)
+	})
+	return posts, nil
+}
+
+// loadPost reads a post from a Markdown file that starts with YAML front matter between "---"
+// lines. The title and the date are required.
+func loadPost(path string) (models.Post, error) {
+	post := models.Post{Slug: strings.TrimSuffix(filepath.Base(path), ".md")}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return post, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	frontMatter, body, ok := splitFrontMatter(content)
+	if !ok {
+		return post, fmt.Errorf("%s: post must start with front matter between %q lines", path, frontMatterDelimiter)
+	}
+	if err := yaml.Unmarshal(frontMatter, &post); err != nil {
+		return post, fmt.Errorf("failed to unmarshal front matter from %s: %w", path, err)
+	}
+	if post.Title == "" {
+		return post, fmt.Errorf("%s: title is required", path)
+	}
+	if post.Date.IsZero() {
+		return post, fmt.Errorf("%s: date is required", path)
+	}
+	post.Body = string(body)
+	return post, nil
+}
+
+// splitFrontMatter splits a post into its front matter and its body.
+func splitFrontMatter(content []byte) (frontMatter, body []byte, ok bool) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	rest, ok := bytes.CutPrefix(content, []byte(frontMatterDelimiter+"\n"))
+	if !ok {
+		return nil, nil, false
+	}
+	if after, ok := bytes.CutPrefix(rest, []byte(frontMatterDelimiter+"\n")); ok {
+		return nil, after, true // Empty front matter
+	}
+	frontMatter, body, ok = bytes.Cut(rest, []byte("\n"+frontMatterDelimiter+"\n"))
+	if !ok {
+		// The closing delimiter may end the file
+		if frontMatter, ok = bytes.CutSuffix(rest, []byte("\n"+frontMatterDelimiter)); !ok {
+			return nil, nil, false
+		}
+	}
+	return frontMatter, bytes.TrimLeft(body, "\n"), true
+}
@@ -0,0 +1,97 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPosts(t *testing.T) {
+	tempDir := t.TempDir()
+	writePost := func(dir, name, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	postsDir := filepath.Join(tempDir, "posts")
+	esDir := filepath.Join(tempDir, "lang", "es", "posts")
+	writePost(postsDir, "hello.md", "---\ntitle: Hello\ndate: 2024-01-10\ntags: [go, blog]\n---\n# Hello\n")
+	writePost(postsDir, "later.md", "---\ntitle: Later\ndate: 2024-03-01\ndraft: true\n---\nNot yet.\n")
+	writePost(postsDir, "alpha.md", "---\ntitle: Alpha\ndate: 2024-01-10\n---\nSame day.\n")
+	writePost(postsDir, "notes.txt", "not a post")
+	writePost(esDir, "hello.md", "---\ntitle: Hola\ndate: 2024-01-10\n---\n# Hola\n")
+	writePost(esDir, "solo.md", "---\ntitle: Solo\ndate: 2023-12-24\n---\nSolo en español.\n")
+
+	t.Run("Default language", func(t *testing.T) {
+		posts, err := LoadPosts(tempDir, "en")
+		require.NoError(t, err)
+		require.Len(t, posts, 3)
+		assert.Equal(t, "later", posts[0].Slug)
+		assert.True(t, posts[0].Draft, "drafts are loaded")
+		assert.Equal(t, "alpha", posts[1].Slug, "posts of the same day are sorted by slug")
+		assert.Equal(t, "hello", posts[2].Slug)
+		assert.Equal(t, "Hello", posts[2].Title)
+		assert.Equal(t, []string{"go", "blog"}, posts[2].Tags)
+		assert.Equal(t, "# Hello\n", posts[2].Body)
+	})
+
+	t.Run("Specific language", func(t *testing.T) {
+		posts, err := LoadPosts(tempDir, "es")
+		require.NoError(t, err)
+		require.Len(t, posts, 4)
+		assert.Equal(t, "hello", posts[2].Slug)
+		assert.Equal(t, "Hola", posts[2].Title, "language posts replace the default ones")
+		assert.Equal(t, "solo", posts[3].Slug)
+	})
+
+	t.Run("No posts", func(t *testing.T) {
+		posts, err := LoadPosts(t.TempDir(), "en")
+		assert.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+
+	t.Run("Invalid posts", func(t *testing.T) {
+		invalid := map[string]string{
+			"No front matter": "# Hello\n",
+			"No title":        "---\ndate: 2024-01-10\n---\n",
+			"No date":         "---\ntitle: Hello\n---\n",
+			"Invalid YAML":    "---\ntitle: [Hello\n---\n",
+		}
+		for name, content := range invalid {
+			dir := t.TempDir()
+			writePost(filepath.Join(dir, "posts"), "post.md", content)
+			_, err := LoadPosts(dir, "en")
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		wantFrontMatter string
+		wantBody        string
+		wantOK          bool
+	}{
+		{name: "Front matter and body", content: "---\ntitle: Hello\n---\n\nBody\n", wantFrontMatter: "title: Hello", wantBody: "Body\n", wantOK: true},
+		{name: "Windows line endings", content: "---\r\ntitle: Hello\r\n---\r\nBody\r\n", wantFrontMatter: "title: Hello", wantBody: "Body\n", wantOK: true},
+		{name: "Empty front matter", content: "---\n---\nBody\n", wantBody: "Body\n", wantOK: true},
+		{name: "Closing delimiter at the end", content: "---\ntitle: Hello\n---", wantFrontMatter: "title: Hello", wantOK: true},
+		{name: "Delimiter inside the body", content: "---\ntitle: Hello\n---\nOne\n---\nTwo\n", wantFrontMatter: "title: Hello", wantBody: "One\n---\nTwo\n", wantOK: true},
+		{name: "No front matter", content: "# Hello\n"},
+		{name: "Unclosed front matter", content: "---\ntitle: Hello\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontMatter, body, ok := splitFrontMatter([]byte(tt.content))
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantFrontMatter, string(frontMatter))
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+// SaveResumeData writes every non-empty section of data into dataDir as the per-section
+// YAML files read by LoadResumeData (basic.yml, professional.yml, ...).
+// Existing files are only replaced when overwrite is true. It returns the paths written.
+func SaveResumeData(dataDir string, data *models.ResumeData, overwrite bool) ([]string, error) {
+	if data == nil {
+		return nil, fmt.Errorf("resume data cannot be nil")
+	}
+
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	type sectionFile struct {
+		path    string
+		content []byte
+	}
+
+	// Encode every section and check for existing files before writing anything
+	var files []sectionFile
+	for _, section := range Sections() {
+		target := supportedFiles[section](data)
+		if reflect.ValueOf(target).Elem().IsZero() {
+			continue
+		}
+
+		path := filepath.Join(dataDir, fmt.Sprintf("%s.%s", section, extensions[0]))
+		if !overwrite {
+			if _, err := os.Stat(path); err == nil {
+				return nil, fmt.Errorf("file %s already exists", path)
+			}
+		}
+
+		content, err := marshalSection(target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", section, err)
+		}
+		files = append(files, sectionFile{path: path, content: content})
+	}
+
+	var written []string
+	for _, f := range files {
+		if err := os.WriteFile(f.path, f.content, 0644); err != nil {
+			return written, fmt.Errorf("failed to write file %s: %w", f.path, err)
+		}
+		written = append(written, f.path)
+	}
+
+	return written, nil
+}
+
+// marshalSection encodes a section in the block style used by the hand-written data files:
+// the models' `flow` tags are ignored, multi-line strings become literal blocks and
+// midnight timestamps are written as plain dates.
+func marshalSection(section any) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(section); err != nil {
+		return nil, err
+	}
+	normalizeStyle(&node)
+
+	var buf strings.Builder
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
+
+func normalizeStyle(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		node.Style = 0
+	case yaml.ScalarNode:
+		// Scalars inside flow collections come back quoted, timestamps included
+		node.Style = 0
+		if strings.Contains(node.Value, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+		if t, err := time.Parse(time.RFC3339, node.Value); err == nil {
+			node.Tag = "!!timestamp"
+			if t.Equal(t.Truncate(24 * time.Hour)) {
+				node.Value = t.Format(time.DateOnly)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		normalizeStyle(child)
+	}
+}
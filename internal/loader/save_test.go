@@ -0,0 +1,70 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+func TestSaveResumeData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_save")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	end := time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC)
+	data := &models.ResumeData{
+		Basic: models.BasicData{Name: "John Doe", Summary: "Line one\nLine two\n"},
+		Professional: models.ProfessionalData{
+			Title: "Engineer",
+			Jobs: []models.Job{{
+				Position:       "Developer",
+				StartDate:      time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:        &end,
+				JobDescription: "- Shipped X\n- Led Y\n",
+				Company:        models.Entity{Name: "Acme", URL: "https://acme.com"},
+			}},
+		},
+	}
+
+	t.Run("Write sections", func(t *testing.T) {
+		written, err := SaveResumeData(tempDir, data, false)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			filepath.Join(tempDir, "basic.yml"),
+			filepath.Join(tempDir, "professional.yml"),
+		}, written)
+
+		content, err := os.ReadFile(filepath.Join(tempDir, "professional.yml"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "start_date: 2019-03-01\n")
+		assert.Contains(t, string(content), "job_description: |\n")
+	})
+
+	t.Run("Round trip through the loader", func(t *testing.T) {
+		loaded, err := LoadResumeData(tempDir, "")
+		assert.NoError(t, err)
+		assert.Equal(t, data.Basic, loaded.Basic)
+		assert.Equal(t, data.Professional, loaded.Professional)
+
+		issues, err := ValidateResumeData(tempDir)
+		assert.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("Refuse to overwrite", func(t *testing.T) {
+		_, err := SaveResumeData(tempDir, data, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		_, err := SaveResumeData(tempDir, data, true)
+		assert.NoError(t, err)
+	})
+}
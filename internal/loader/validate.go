@@ -0,0 +1,334 @@
+package loader
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/odinnordico/odinnordico.github.io/internal/models"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// allowedURLSchemes lists the URL schemes accepted by the "url" validation rule.
+var allowedURLSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"tel":    true,
+}
+
+// Issue describes a single problem found in a data file.
+type Issue struct {
+	File    string
+	Line    int
+	Column  int
+	Field   string
+	Message string
+}
+
+// String formats the issue as "file:line:column: field: message".
+func (i Issue) String() string {
+	if i.Field == "" {
+		return fmt.Sprintf("%s:%d:%d: %s", i.File, i.Line, i.Column, i.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", i.File, i.Line, i.Column, i.Field, i.Message)
+}
+
+// ValidateResumeData checks every base data file and every data/lang/<lang> overlay
+// against the models, reporting unknown keys, type mismatches and the rules declared
+// in the `validate` struct tags. Required fields are only enforced on base files,
+// because overlays inherit anything they omit from the base data.
+func ValidateResumeData(dataDir string) ([]Issue, error) {
+	var issues []Issue
+
+	found, err := validateDir(dataDir, false)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, found...)
+
+	langs, err := os.ReadDir(filepath.Join(dataDir, "lang"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read language directory: %w", err)
+	}
+	for _, lang := range langs {
+		if !lang.IsDir() {
+			continue
+		}
+		found, err := validateDir(filepath.Join(dataDir, "lang", lang.Name()), true)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
+}
+
+// validateDir validates every supported section file found in dir.
+func validateDir(dir string, overlay bool) ([]Issue, error) {
+	var issues []Issue
+	for _, section := range Sections() {
+		for _, ext := range extensions {
+			path := filepath.Join(dir, fmt.Sprintf("%s.%s", section, ext))
+			content, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+			}
+
+			target := supportedFiles[section](&models.ResumeData{})
+			v := &fileValidator{file: path, overlay: overlay}
+			v.validate(content, reflect.TypeOf(target).Elem())
+			sort.SliceStable(v.issues, func(i, j int) bool {
+				if v.issues[i].Line != v.issues[j].Line {
+					return v.issues[i].Line < v.issues[j].Line
+				}
+				return v.issues[i].Column < v.issues[j].Column
+			})
+			issues = append(issues, v.issues...)
+		}
+	}
+	return issues, nil
+}
+
+// fileValidator walks the YAML node tree of a single file alongside the Go type it decodes into.
+type fileValidator struct {
+	file    string
+	overlay bool
+	issues  []Issue
+}
+
+func (v *fileValidator) validate(content []byte, t reflect.Type) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		v.issues = append(v.issues, Issue{File: v.file, Line: yamlErrorLine(err), Message: err.Error()})
+		return
+	}
+	if len(doc.Content) == 0 {
+		return
+	}
+	v.walk(doc.Content[0], t, "")
+}
+
+func (v *fileValidator) report(node *yaml.Node, field, format string, args ...any) {
+	v.issues = append(v.issues, Issue{
+		File:    v.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// walk checks that node has the shape expected by t and applies the field rules of nested structs.
+func (v *fileValidator) walk(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" {
+		return
+	}
+
+	switch {
+	case t == timeType:
+		var ts time.Time
+		if err := node.Decode(&ts); err != nil {
+			v.report(node, path, "invalid date %q", node.Value)
+		}
+	case t.Kind() == reflect.Ptr:
+		v.walk(node, t.Elem(), path)
+	case t.Kind() == reflect.Struct:
+		v.walkStruct(node, t, path)
+	case t.Kind() == reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.report(node, path, "expected a list")
+			return
+		}
+		for i, item := range node.Content {
+			v.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		if node.Kind != yaml.ScalarNode {
+			v.report(node, path, "expected a %s value", t.Kind())
+			return
+		}
+		if err := node.Decode(reflect.New(t).Interface()); err != nil {
+			v.report(node, path, "expected a %s value, got %q", t.Kind(), node.Value)
+		}
+	}
+}
+
+// walkStruct checks a mapping node against the yaml fields of t and enforces their validate tags.
+func (v *fileValidator) walkStruct(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind != yaml.MappingNode {
+		v.report(node, path, "expected a mapping")
+		return
+	}
+
+	fields := yamlFields(t)
+	values := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field, ok := findField(fields, key.Value)
+		if !ok {
+			v.report(key, joinPath(path, key.Value), "unknown key")
+			continue
+		}
+		values[key.Value] = value
+		v.walk(value, field.Type, joinPath(path, key.Value))
+	}
+
+	for _, field := range fields {
+		v.checkRules(node, values, fields, field, joinPath(path, field.name))
+	}
+}
+
+// checkRules applies the comma-separated rules of a field's validate tag.
+func (v *fileValidator) checkRules(parent *yaml.Node, values map[string]*yaml.Node, fields []yamlField, field yamlField, path string) {
+	tag := field.Tag.Get("validate")
+	if tag == "" {
+		return
+	}
+
+	value, present := values[field.name]
+	for _, rule := range strings.Split(tag, ",") {
+		key, arg, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			if !v.overlay && (!present || isEmptyNode(value)) {
+				v.report(parent, path, "is required")
+			}
+		case "min", "max":
+			if !present || value.Kind != yaml.ScalarNode {
+				continue
+			}
+			n, err := strconv.ParseFloat(value.Value, 64)
+			limit, _ := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				continue
+			}
+			if key == "min" && n < limit {
+				v.report(value, path, "must be at least %s, got %s", arg, value.Value)
+			}
+			if key == "max" && n > limit {
+				v.report(value, path, "must be at most %s, got %s", arg, value.Value)
+			}
+		case "url":
+			if !present || isEmptyNode(value) {
+				continue
+			}
+			if err := checkURL(value.Value); err != nil {
+				v.report(value, path, "%v", err)
+			}
+		case "gtefield":
+			other := yamlName(fields, arg)
+			otherValue, ok := values[other]
+			if !present || !ok || isEmptyNode(value) || isEmptyNode(otherValue) {
+				continue
+			}
+			var end, start time.Time
+			if value.Decode(&end) != nil || otherValue.Decode(&start) != nil {
+				continue
+			}
+			if end.Before(start) {
+				v.report(value, path, "must not be before %s", other)
+			}
+		}
+	}
+}
+
+// yamlField is a struct field together with the yaml key it is decoded from.
+type yamlField struct {
+	reflect.StructField
+	name string
+}
+
+// yamlFields returns the exported fields of t in declaration order, keyed by their yaml names.
+func yamlFields(t reflect.Type) []yamlField {
+	fields := make([]yamlField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields = append(fields, yamlField{StructField: field, name: name})
+	}
+	return fields
+}
+
+// findField looks up the field decoded from the yaml key name.
+func findField(fields []yamlField, name string) (yamlField, bool) {
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	return yamlField{}, false
+}
+
+// yamlName returns the yaml key of the Go field called goName.
+func yamlName(fields []yamlField, goName string) string {
+	for _, field := range fields {
+		if field.Name == goName {
+			return field.name
+		}
+	}
+	return goName
+}
+
+// checkURL verifies that raw is an absolute URL with a supported scheme.
+func checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q", raw)
+	}
+	if !allowedURLSchemes[u.Scheme] {
+		return fmt.Errorf("URL %q must use one of http, https, mailto or tel", raw)
+	}
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return fmt.Errorf("URL %q has no host", raw)
+	}
+	if (u.Scheme == "mailto" || u.Scheme == "tel") && u.Opaque == "" {
+		return fmt.Errorf("URL %q has no address", raw)
+	}
+	return nil
+}
+
+func isEmptyNode(node *yaml.Node) bool {
+	return node.Tag == "!!null" || (node.Kind == yaml.ScalarNode && strings.TrimSpace(node.Value) == "")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// yamlErrorLine extracts the line number from a yaml.v3 syntax error, or 0 if there is none.
+func yamlErrorLine(err error) int {
+	var line int
+	if _, scanErr := fmt.Sscanf(err.Error(), "yaml: line %d:", &line); scanErr != nil {
+		return 0
+	}
+	return line
+}
@@ -0,0 +1,71 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateResumeData(t *testing.T) {
+	// newDataDir returns a data directory of its own for each subtest, and its Spanish directory
+	newDataDir := func(t *testing.T) (string, string) {
+		t.Helper()
+		dataDir := t.TempDir()
+		langDir := filepath.Join(dataDir, "lang", "es")
+		if err := os.MkdirAll(langDir, 0755); err != nil {
+			t.Fatalf("Failed to create lang dir: %v", err)
+		}
+		return dataDir, langDir
+	}
+
+	t.Run("Valid data", func(t *testing.T) {
+		tempDir, langDir := newDataDir(t)
+		createYAMLFile(t, tempDir, "basic.yml", "name: John Doe\nwebsite: https://example.com\n")
+		createYAMLFile(t, tempDir, "professional.yml", "jobs:\n  - position: Engineer\n    start_date: 2020-01-01\n    end_date: 2021-01-01\n")
+		createYAMLFile(t, langDir, "basic.yml", "summary: Hola\n")
+
+		issues, err := ValidateResumeData(tempDir)
+		assert.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("Invalid data", func(t *testing.T) {
+		tempDir, langDir := newDataDir(t)
+		createYAMLFile(t, tempDir, "basic.yml", "nmae: John Doe\nwebsite: example.com\n")
+		createYAMLFile(t, tempDir, "professional.yml", "jobs:\n  - position: Engineer\n    start_date: 2020-01-01\n    end_date: 2019-01-01\n")
+		createYAMLFile(t, tempDir, "skills.yml", "- name: Go\n  level: 11\n")
+		createYAMLFile(t, langDir, "basic.yml", "sumary: Hola\n")
+
+		issues, err := ValidateResumeData(tempDir)
+		assert.NoError(t, err)
+
+		var messages []string
+		for _, issue := range issues {
+			messages = append(messages, issue.String())
+		}
+		basicPath := filepath.Join(tempDir, "basic.yml")
+		assert.Contains(t, messages, basicPath+":1:1: nmae: unknown key")
+		assert.Contains(t, messages, basicPath+":1:1: name: is required")
+		assert.Contains(t, messages, basicPath+`:2:10: website: URL "example.com" must use one of http, https, mailto or tel`)
+		assert.Contains(t, messages, filepath.Join(tempDir, "professional.yml")+":4:15: jobs[0].end_date: must not be before start_date")
+		assert.Contains(t, messages, filepath.Join(tempDir, "skills.yml")+":2:10: [0].level: must be at most 10, got 11")
+		assert.Contains(t, messages, filepath.Join(langDir, "basic.yml")+":1:1: sumary: unknown key")
+		assert.Len(t, issues, 6)
+	})
+
+	t.Run("Malformed YAML", func(t *testing.T) {
+		tempDir, langDir := newDataDir(t)
+		createYAMLFile(t, tempDir, "basic.yml", "name: [John\n")
+		createYAMLFile(t, tempDir, "professional.yml", "jobs:\n  - position: Engineer\n    start_date: 2020-01-01\n")
+		createYAMLFile(t, langDir, "basic.yml", "summary: Hola\n")
+
+		issues, err := ValidateResumeData(tempDir)
+		assert.NoError(t, err)
+		if assert.Len(t, issues, 1) {
+			assert.Equal(t, filepath.Join(tempDir, "basic.yml"), issues[0].File)
+			assert.Equal(t, 1, issues[0].Line)
+		}
+	})
+}
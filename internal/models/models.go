@@ -3,82 +3,161 @@ package models
 
 import "time"
 
+// Keyed is implemented by list entries that language overlays merge by key rather than by position.
+// MergeKey returns the explicit ID when one is set, otherwise a natural key built from
+// language-independent fields, or "" when neither is available.
+type Keyed interface {
+	MergeKey() string
+}
+
 // ResumeData represents the complete resume data structure containing all sections.
 type ResumeData struct {
-	Basic        BasicData        `yaml:"basic,omitempty"`
-	Professional ProfessionalData `yaml:"professional,omitempty"`
-	Certificates []Certificate    `yaml:"certificates,flow"`
-	Education    []Education      `yaml:"education,flow"`
-	Skills       []Skill          `yaml:"skills,flow"`
-	Social       []Entity         `yaml:"social,flow"`
+	Basic        BasicData        `yaml:"basic,omitempty" json:"basic,omitzero"`
+	Professional ProfessionalData `yaml:"professional,omitempty" json:"professional,omitzero"`
+	Certificates []Certificate    `yaml:"certificates,flow" json:"certificates,omitempty"`
+	Education    []Education      `yaml:"education,flow" json:"education,omitempty"`
+	Skills       []Skill          `yaml:"skills,flow" json:"skills,omitempty"`
+	Social       []Entity         `yaml:"social,flow" json:"social,omitempty"`
 }
 
 // BasicData contains basic personal information.
 type BasicData struct {
-	Name          string `yaml:"name"`
-	DisplayName   string `yaml:"display_name,omitempty"`
-	Location      string `yaml:"location,omitempty"`
-	Pronunciation string `yaml:"pronunciation,omitempty"`
-	Phrase        string `yaml:"phrase,omitempty"`
-	Summary       string `yaml:"summary,omitempty"`
-	Website       string `yaml:"website,omitempty"`
+	Name          string `yaml:"name" json:"name,omitempty" validate:"required"`
+	DisplayName   string `yaml:"display_name,omitempty" json:"display_name,omitempty"`
+	Location      string `yaml:"location,omitempty" json:"location,omitempty"`
+	Pronunciation string `yaml:"pronunciation,omitempty" json:"pronunciation,omitempty"`
+	Phrase        string `yaml:"phrase,omitempty" json:"phrase,omitempty" i18n:"text"`
+	Summary       string `yaml:"summary,omitempty" json:"summary,omitempty" i18n:"text"`
+	Website       string `yaml:"website,omitempty" json:"website,omitempty" validate:"url"`
 }
 
 // ProfessionalData contains professional experience information.
 type ProfessionalData struct {
-	Title             string  `yaml:"title,omitempty"`
-	YearsOfExperience float64 `yaml:"years_of_experience,omitempty"`
-	Jobs              []Job   `yaml:"jobs,flow"`
+	Title             string  `yaml:"title,omitempty" json:"title,omitempty" i18n:"text"`
+	YearsOfExperience float64 `yaml:"years_of_experience,omitempty" json:"years_of_experience,omitempty"`
+	Jobs              []Job   `yaml:"jobs,flow" json:"jobs,omitempty"`
 }
 
 // Job represents a work experience entry.
 type Job struct {
-	Position       string     `yaml:"position,omitempty"`
-	StartDate      time.Time  `yaml:"start_date,omitempty"`
-	EndDate        *time.Time `yaml:"end_date,omitempty"` // nil means current position
-	JobDescription string     `yaml:"job_description,omitempty"`
-	Company        Entity     `yaml:"company,omitempty"`
+	ID             string     `yaml:"id,omitempty" json:"id,omitempty"`
+	Position       string     `yaml:"position,omitempty" json:"position,omitempty" validate:"required" i18n:"text"`
+	StartDate      time.Time  `yaml:"start_date,omitempty" json:"start_date,omitzero" validate:"required"`
+	EndDate        *time.Time `yaml:"end_date,omitempty" json:"end_date,omitempty" validate:"gtefield=StartDate"` // nil means current position
+	JobDescription string     `yaml:"job_description,omitempty" json:"job_description,omitempty" i18n:"text"`
+	Company        Entity     `yaml:"company,omitempty" json:"company,omitzero"`
 }
 
 // Entity represents an organization or social media account with optional logo.
 type Entity struct {
-	Name string `yaml:"name,omitempty"`
-	URL  string `yaml:"url,omitempty"`
-	Logo Logo   `yaml:"logo,omitempty"`
+	ID   string `yaml:"id,omitempty" json:"id,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	URL  string `yaml:"url,omitempty" json:"url,omitempty" validate:"url"`
+	Logo Logo   `yaml:"logo,omitempty" json:"logo,omitzero"`
 }
 
 // Logo represents branding information for an entity.
 type Logo struct {
-	Library string `yaml:"library,omitempty"` // Icon library (e.g., "brands", "solid")
-	Image   string `yaml:"image,omitempty"`   // Icon name
+	Library string `yaml:"library,omitempty" json:"library,omitempty"` // Icon library (e.g., "brands", "solid")
+	Image   string `yaml:"image,omitempty" json:"image,omitempty"`     // Icon name
 }
 
 // Certificate represents a professional certification.
 type Certificate struct {
-	Name           string    `yaml:"name,omitempty"`
-	Description    string    `yaml:"description,omitempty"`
-	Date           time.Time `yaml:"date,omitempty"`
-	CertificateURL string    `yaml:"certificate_url,omitempty"`
-	URL            string    `yaml:"url,omitempty,omitempty"`
-	Provider       Entity    `yaml:"provider,omitempty"`
-	Topics         []string  `yaml:"topics,flow"`
+	ID             string    `yaml:"id,omitempty" json:"id,omitempty"`
+	Name           string    `yaml:"name,omitempty" json:"name,omitempty"`
+	Description    string    `yaml:"description,omitempty" json:"description,omitempty" i18n:"text"`
+	Date           time.Time `yaml:"date,omitempty" json:"date,omitzero"`
+	CertificateURL string    `yaml:"certificate_url,omitempty" json:"certificate_url,omitempty" validate:"url"`
+	URL            string    `yaml:"url,omitempty,omitempty" json:"url,omitempty" validate:"url"`
+	Provider       Entity    `yaml:"provider,omitempty" json:"provider,omitzero"`
+	Topics         []string  `yaml:"topics,flow" json:"topics,omitempty" i18n:"text"`
 }
 
 // Education represents an education entry.
 type Education struct {
-	Title       string    `yaml:"title,omitempty"`
-	Date        time.Time `yaml:"date,omitempty"`
-	URL         string    `yaml:"url,omitempty"`
-	Level       string    `yaml:"level,omitempty"`
-	Provider    Entity    `yaml:"provider,omitempty"`
-	Description string    `yaml:"description,omitempty"`
+	ID          string    `yaml:"id,omitempty" json:"id,omitempty"`
+	Title       string    `yaml:"title,omitempty" json:"title,omitempty" i18n:"text"`
+	Date        time.Time `yaml:"date,omitempty" json:"date,omitzero"`
+	URL         string    `yaml:"url,omitempty" json:"url,omitempty" validate:"url"`
+	Level       string    `yaml:"level,omitempty" json:"level,omitempty" i18n:"text"`
+	Provider    Entity    `yaml:"provider,omitempty" json:"provider,omitzero"`
+	Description string    `yaml:"description,omitempty" json:"description,omitempty" i18n:"text"`
 }
 
 // Skill represents a skill entry with proficiency level.
 type Skill struct {
-	Name        string   `yaml:"name,omitempty"`
-	Description string   `yaml:"description,omitempty"`
-	Level       int      `yaml:"level,omitempty" validate:"min=1,max=10"`
-	Logo        Logo     `yaml:"logo,omitempty"`
-	Tags        []string `yaml:"tags,flow"`
+	ID          string   `yaml:"id,omitempty" json:"id,omitempty"`
+	Name        string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty" i18n:"text"`
+	Level       int      `yaml:"level,omitempty" json:"level,omitempty" validate:"min=1,max=10"`
+	Logo        Logo     `yaml:"logo,omitempty" json:"logo,omitzero"`
+	Tags        []string `yaml:"tags,flow" json:"tags,omitempty"`
+}
+
+// Post represents an article of the blog, read from a Markdown file with YAML front matter.
+type Post struct {
+	Slug  string    `yaml:"-" json:"slug"` // Name of the file without the .md extension
+	Title string    `yaml:"title" json:"title"`
+	Date  time.Time `yaml:"date" json:"date"`
+	Tags  []string  `yaml:"tags,flow,omitempty" json:"tags,omitempty"`
+	Draft bool      `yaml:"draft,omitempty" json:"draft,omitempty"`
+	Body  string    `yaml:"-" json:"body"` // Markdown content after the front matter
+}
+
+// MergeKey identifies a job by its ID, or by company name and start date.
+func (j Job) MergeKey() string {
+	if j.ID != "" {
+		return j.ID
+	}
+	if j.Company.Name == "" || j.StartDate.IsZero() {
+		return ""
+	}
+	return j.Company.Name + "@" + j.StartDate.Format(time.DateOnly)
+}
+
+// MergeKey identifies an entity by its ID, or by its URL.
+func (e Entity) MergeKey() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.URL
+}
+
+// MergeKey identifies a certificate by its ID, its certificate or course URL, or its name and date.
+func (c Certificate) MergeKey() string {
+	switch {
+	case c.ID != "":
+		return c.ID
+	case c.CertificateURL != "":
+		return c.CertificateURL
+	case c.URL != "":
+		return c.URL
+	case c.Name == "" || c.Date.IsZero():
+		return ""
+	}
+	return c.Name + "@" + c.Date.Format(time.DateOnly)
+}
+
+// MergeKey identifies an education entry by its ID, or by its program or provider URL and date.
+func (e Education) MergeKey() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	url := e.URL
+	if url == "" {
+		url = e.Provider.URL
+	}
+	if url == "" || e.Date.IsZero() {
+		return ""
+	}
+	return url + "@" + e.Date.Format(time.DateOnly)
+}
+
+// MergeKey identifies a skill by its ID, or by its name.
+func (s Skill) MergeKey() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return s.Name
 }
@@ -0,0 +1,100 @@
+// Package vcs reads revision information about data files from the git repository that contains them.
+package vcs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LineTimes returns the time each line of the file at path was last changed, indexed from 1
+// (index 0 is unused). Committed lines use their commit time and uncommitted lines the time
+// git reports for the working tree. When the file is not tracked by git, or git is not
+// available, every line gets the file's modification time.
+func LineTimes(path string) ([]time.Time, error) {
+	times, err := blameTimes(path)
+	if err == nil {
+		return times, nil
+	}
+
+	info, statErr := os.Stat(path)
+	if statErr != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, statErr)
+	}
+	content, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, readErr)
+	}
+
+	times = make([]time.Time, bytes.Count(content, []byte("\n"))+2)
+	for i := range times {
+		times[i] = info.ModTime()
+	}
+	return times, nil
+}
+
+// blameTimes runs git blame on path and maps every line to its committer time.
+func blameTimes(path string) ([]time.Time, error) {
+	cmd := exec.Command("git", "blame", "--porcelain", "--", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git blame %s: %w", path, err)
+	}
+
+	commitTimes := make(map[string]time.Time)
+	times := []time.Time{{}}
+	var sha string
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			times = append(times, commitTimes[sha])
+		case strings.HasPrefix(line, "committer-time "):
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(line, "committer-time "), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse git blame output: %w", err)
+			}
+			commitTimes[sha] = time.Unix(seconds, 0)
+		default:
+			if fields := strings.Fields(line); len(fields) >= 3 && (len(fields[0]) == 40 || len(fields[0]) == 64) {
+				sha = fields[0]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read git blame output: %w", err)
+	}
+
+	return times, nil
+}
+
+// LastCommitTime returns the committer time of the last commit that changed a file under dir.
+// It fails when dir is not in a git repository, git is not available, or no commit touches dir.
+func LastCommitTime(dir string) (time.Time, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%ct", "--", ".")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("git log %s: %w", dir, err)
+	}
+
+	value := strings.TrimSpace(string(out))
+	if value == "" {
+		return time.Time{}, fmt.Errorf("git log %s: no commits", dir)
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse git log output: %w", err)
+	}
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,84 @@
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineTimes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test_vcs")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "basic.yml")
+	if err := os.WriteFile(path, []byte("name: John Doe\nsummary: Builds things\n"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set file time: %v", err)
+	}
+
+	t.Run("Untracked file falls back to modification time", func(t *testing.T) {
+		times, err := LineTimes(path)
+		assert.NoError(t, err)
+		if assert.GreaterOrEqual(t, len(times), 3) {
+			assert.True(t, times[1].Equal(modTime))
+			assert.True(t, times[2].Equal(modTime))
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LineTimes(filepath.Join(tempDir, "missing.yml"))
+		assert.Error(t, err)
+	})
+}
+
+func TestLastCommitTime(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	tempDir := t.TempDir()
+	dataDir := filepath.Join(tempDir, "data")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	t.Run("Directory outside a repository", func(t *testing.T) {
+		_, err := LastCommitTime(dataDir)
+		assert.Error(t, err)
+	})
+
+	commitTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tempDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=John Doe", "GIT_AUTHOR_EMAIL=john@example.com",
+			"GIT_COMMITTER_NAME=John Doe", "GIT_COMMITTER_EMAIL=john@example.com",
+			"GIT_COMMITTER_DATE="+commitTime.Format(time.RFC3339),
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	if err := os.WriteFile(filepath.Join(dataDir, "basic.yml"), []byte("name: John Doe\n"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "Add data")
+
+	t.Run("Last commit of the directory", func(t *testing.T) {
+		got, err := LastCommitTime(dataDir)
+		assert.NoError(t, err)
+		assert.True(t, got.Equal(commitTime), "got %v", got)
+	})
+}
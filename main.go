@@ -33,6 +33,9 @@ var RootCmd = &cobra.Command{
 	Long: `odinnordico.github.io is a tool that reads YAML files containing resume and portfolio data
 and generates a static website and PDF resume that match specified designs.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Commands sharing a flag, such as theme or drafts, each bind it in init and the last
+		// binding wins, so bind the flags of the command that runs again
+		viper.BindPFlags(cmd.Flags())
 		initConfig()
 	},
 }
@@ -41,12 +44,18 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.odinnordico.github.io.yaml)")
 	RootCmd.PersistentFlags().String("data-dir", "data", "directory containing YAML data files")
 	RootCmd.PersistentFlags().String("output-dir", "public", "output directory for generated files")
+	RootCmd.PersistentFlags().StringArray("theme-opt", nil, "theme option as name=value, overriding theme-options of the config file (repeatable)")
 
 	viper.BindPFlag("data-dir", RootCmd.PersistentFlags().Lookup("data-dir"))
 	viper.BindPFlag("output-dir", RootCmd.PersistentFlags().Lookup("output-dir"))
+	viper.BindPFlag("theme-opt", RootCmd.PersistentFlags().Lookup("theme-opt"))
 
+	RootCmd.AddCommand(cmd.ExportCmd)
+	RootCmd.AddCommand(cmd.I18nCmd)
+	RootCmd.AddCommand(cmd.ImportCmd)
 	RootCmd.AddCommand(cmd.PdfCmd)
 	RootCmd.AddCommand(cmd.ServeCmd)
+	RootCmd.AddCommand(cmd.ValidateCmd)
 	RootCmd.AddCommand(cmd.WebsiteCmd)
 }
 